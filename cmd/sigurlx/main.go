@@ -2,13 +2,20 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"log"
 	"os"
-	"sync"
-	"time"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/drsigned/gos"
 	"github.com/drsigned/sigurlx/pkg/params"
@@ -18,11 +25,43 @@ import (
 
 type options struct {
 	delay        int
+	headerEnv    string
 	threads      int
+	matchStatus  string
 	output       string
+	SARIF        string
+	paramStats   string
+	nuclei       string
+	hosts        string
+	byHost       string
+	unique       string
+	endpoints    string
+	links        string
+	reflect      string
+	reflectTypes string
+	ignoreParams string
+	proxies      string
+	DOMGroups    string
+	fields       string
+	findingsOnly bool
+	JSON         bool
+	fuzz         bool
 	noColor      bool
 	URLs         string
+	JSONL        bool
+	scope        string
+	sitemap      string
+	responses    string
+	include      string
+	exclude      string
+	bodyMatch    string
+	bodyFilter   string
+	template     string
+	rawHeaders   string
 	updateParams bool
+	paramsFiles  string
+	tags         string
+	stats        bool
 	verbose      bool
 }
 
@@ -46,18 +85,118 @@ func banner() {
 func init() {
 	// general options
 	flag.StringVar(&co.URLs, "iL", "", "")
+	flag.BoolVar(&co.JSONL, "jsonl", false, "")
+	flag.StringVar(&co.sitemap, "sitemap", "", "")
+	flag.StringVar(&co.responses, "responses", "", "")
+	flag.StringVar(&co.include, "include", "", "")
+	flag.StringVar(&co.exclude, "exclude", "", "")
+	flag.StringVar(&co.scope, "scope", "", "")
+	flag.StringVar(&ro.ScanID, "scan-id", "", "")
+	flag.StringVar(&co.tags, "tags", "", "")
+	flag.BoolVar(&ro.SkipThirdParty, "skip-third-party", false, "")
+	flag.BoolVar(&ro.Lenient, "lenient", false, "")
 	flag.IntVar(&co.threads, "threads", 20, "")
+	flag.BoolVar(&ro.Dedup, "dedup", false, "")
+	flag.StringVar(&co.ignoreParams, "ignore-params", "", "")
+	flag.IntVar(&ro.MaxPerCategory, "max-per-category", 0, "")
+	flag.IntVar(&ro.MaxRunTime, "max-run-time", 0, "")
+	flag.BoolVar(&ro.Shuffle, "shuffle", false, "")
+	flag.IntVar(&ro.PerHostConcurrency, "host-threads", 0, "")
+	flag.BoolVar(&ro.ExtendedCategories, "types", false, "")
+	flag.BoolVar(&ro.CategoryMatch, "category-match", false, "")
 	flag.BoolVar(&co.updateParams, "update-params", false, "")
+	flag.StringVar(&co.paramsFiles, "params-files", "", "")
+	flag.StringVar(&ro.RulesFile, "rules", "", "")
+	flag.BoolVar(&ro.ParamSource, "param-source", false, "")
+	// probe options
+	flag.BoolVar(&ro.CaseInsensitive, "case-insensitive", false, "")
+	flag.BoolVar(&ro.CRLF, "crlf", false, "")
+	flag.BoolVar(&ro.CSP, "csp", false, "")
+	flag.BoolVar(&ro.InlineHandlers, "inline-handlers", false, "")
+	flag.BoolVar(&ro.JWT, "jwt", false, "")
+	flag.BoolVar(&ro.Links, "links", false, "")
+	flag.BoolVar(&ro.MatrixParams, "matrix-params", false, "")
+	flag.BoolVar(&ro.MixedContent, "mixed-content", false, "")
+	flag.BoolVar(&ro.OpenRedirect, "open-redirect", false, "")
+	flag.BoolVar(&ro.DebugDisclosure, "debug-disclosure", false, "")
+	flag.IntVar(&ro.DecodePasses, "decode-passes", 0, "")
+	flag.BoolVar(&ro.DOM, "dom", false, "")
+	flag.StringVar(&co.DOMGroups, "dom-categories", "", "")
+	flag.BoolVar(&ro.DirectoryListing, "dirlisting", false, "")
+	flag.BoolVar(&ro.EncodedReflection, "encoded-reflection", false, "")
+	flag.BoolVar(&ro.ForceChecks, "force-checks", false, "")
+	flag.BoolVar(&ro.FragmentParams, "fragment-params", false, "")
+	flag.BoolVar(&ro.GraphQL, "graphql", false, "")
+	flag.BoolVar(&ro.HandlerPayloads, "handler-payloads", false, "")
+	flag.BoolVar(&ro.HostProbe, "host-probe", false, "")
+	flag.BoolVar(&ro.HPP, "hpp", false, "")
+	flag.BoolVar(&ro.MethodProbe, "method-probe", false, "")
+	flag.BoolVar(&ro.PathReflection, "path-reflection", false, "")
+	flag.StringVar(&ro.PayloadPrefix, "payload-prefix", "", "")
+	flag.StringVar(&ro.PayloadSuffix, "payload-suffix", "", "")
+	flag.IntVar(&ro.ReflectMaxBodySize, "reflect-max-size", 0, "")
+	flag.IntVar(&ro.StreamBodySize, "stream-size", 0, "")
+	flag.IntVar(&ro.MaxParamsPerURL, "max-params", 0, "")
+	flag.StringVar(&co.reflect, "reflect-params", "", "")
+	flag.StringVar(&co.reflectTypes, "reflect-types", "", "")
+	flag.BoolVar(&ro.ReflectionCache, "reflect-cache", false, "")
+	flag.BoolVar(&ro.SchemeDiff, "scheme-diff", false, "")
+	flag.BoolVar(&ro.Secrets, "secrets", false, "")
+	flag.BoolVar(&ro.SecurityHeaders, "security-headers", false, "")
+	flag.BoolVar(&ro.SQLi, "sqli", false, "")
+	flag.BoolVar(&ro.SRI, "sri", false, "")
+	flag.BoolVar(&ro.Swagger, "swagger", false, "")
+	flag.BoolVar(&ro.VCS, "vcs", false, "")
+	flag.BoolVar(&ro.Verify, "verify", false, "")
 	// http options
+	flag.StringVar(&ro.ClientCert, "cert", "", "")
+	flag.StringVar(&ro.ClientKey, "key", "", "")
+	flag.StringVar(&ro.ConditionalCache, "conditional-cache", "", "")
 	flag.IntVar(&co.delay, "delay", 100, "")
+	flag.IntVar(&ro.DNSCacheTTL, "dns-cache-ttl", 0, "")
 	flag.BoolVar(&ro.FollowRedirects, "follow-redirects", false, "")
 	flag.BoolVar(&ro.FollowHostRedirects, "follow-host-redirects", false, "")
-	flag.StringVar(&ro.HTTPProxy, "http-proxy ", "", "")
+	flag.StringVar(&co.headerEnv, "header-env", "", "")
+	flag.BoolVar(&ro.HTTP10, "http10", false, "")
+	flag.StringVar(&co.proxies, "http-proxy", "", "")
+	flag.StringVar(&ro.ProxyRotation, "proxy-rotation", "", "")
+	flag.IntVar(&ro.IdleConnTimeout, "idle-timeout", 90, "")
+	flag.IntVar(&ro.KeepAlive, "keep-alive", 30, "")
+	flag.StringVar(&ro.LocalAddr, "local-addr", "", "")
+	flag.StringVar(&ro.RequestBody, "body", "", "")
+	flag.BoolVar(&ro.CacheBust, "cache-bust", false, "")
+	flag.StringVar(&ro.RequestContentType, "content-type", "", "")
+	flag.StringVar(&ro.ConnectTo, "connect-to", "", "")
+	flag.StringVar(&ro.SNI, "sni", "", "")
+	flag.StringVar(&co.template, "template", "", "")
+	flag.BoolVar(&ro.ProbeScheme, "probe-scheme", false, "")
+	flag.StringVar(&co.rawHeaders, "raw-headers", "", "")
+	flag.StringVar(&ro.ResponseCache, "response-cache", "", "")
+	flag.IntVar(&ro.ResponseCacheTTL, "response-cache-ttl", 0, "")
 	flag.IntVar(&ro.Timeout, "timeout", 10, "")
 	flag.StringVar(&ro.UserAgent, "UA", "", "")
 	// output options
 	flag.BoolVar(&co.noColor, "nC", false, "")
 	flag.StringVar(&co.output, "oJ", "./sigurlx.json", "")
+	flag.StringVar(&co.SARIF, "oS", "", "")
+	flag.StringVar(&co.paramStats, "oP", "", "")
+	flag.StringVar(&co.nuclei, "oN", "", "")
+	flag.StringVar(&co.hosts, "oH", "", "")
+	flag.StringVar(&co.byHost, "oD", "", "")
+	flag.StringVar(&co.unique, "oU", "", "")
+	flag.StringVar(&co.endpoints, "oE", "", "")
+	flag.StringVar(&co.links, "oL", "", "")
+	flag.BoolVar(&co.findingsOnly, "findings-only", false, "")
+	flag.StringVar(&co.matchStatus, "mc", "", "")
+	flag.StringVar(&co.bodyMatch, "mr", "", "")
+	flag.StringVar(&co.bodyFilter, "fr", "", "")
+	flag.BoolVar(&ro.CaptureRaw, "raw", false, "")
+	flag.BoolVar(&co.JSON, "json", false, "")
+	flag.BoolVar(&co.fuzz, "fuzz", false, "")
+	flag.BoolVar(&ro.PrettyJSON, "pretty-json", false, "")
+	flag.BoolVar(&ro.FullJSON, "full-json", false, "")
+	flag.StringVar(&co.fields, "fields", "", "")
+	flag.BoolVar(&co.stats, "stats", false, "")
 	flag.BoolVar(&co.verbose, "v", false, "")
 
 	flag.Usage = func() {
@@ -67,27 +206,195 @@ func init() {
 		h += "  sigurlx [OPTIONS]\n"
 
 		h += "\nGENERAL OPTIONS:\n"
-		h += "  -iL                       input urls list (use `-iL -` to read from stdin)\n"
+		h += "  -iL                       input urls list, optionally gzipped (use `-iL -` to read from stdin)\n"
+		h += "  -jsonl                    input is JSON lines of {\"url\", \"method\", \"headers\", \"body\"}\n"
+		h += "  -sitemap                  sitemap.xml URL to read input urls from\n"
+		h += "  -responses                directory of saved responses to analyze offline\n"
+		h += "  -include                  only process urls matching this regex\n"
+		h += "  -exclude                  skip urls matching this regex\n"
+		h += "  -scope                    comma separated in scope domains, others are third party\n"
+		h += "  -scan-id                  label stamped on every result as scan_id\n"
+		h += "  -tags                     comma separated tags stamped on every result\n"
+		h += "  -skip-third-party         don't send requests to third party urls\n"
+		h += "  -lenient                  skip urls that fail to parse instead of reporting them\n"
+		h += "  -dedup                    skip urls already seen, regardless of param order and -ignore-params\n"
+		h += "  -ignore-params            comma separated params left out when deduping (e.g csrf,_t,timestamp)\n"
 		h += "  -threads                  number concurrent threads (default: 20)\n"
+		h += "  -max-per-category         only scan this many URLs of each category, for sampling (default: all)\n"
+		h += "  -max-run-time             stop the whole scan after this many seconds (default: unlimited)\n"
+		h += "  -shuffle                  process urls in random order\n"
+		h += "  -host-threads             max concurrent requests per host (default: unlimited)\n"
+		h += "  -types                    also classify URLs as api, page or static\n"
+		h += "  -category-match           record the regex and extension or path that decided the category\n"
 		h += "  -update-params            update params file\n"
+		h += "  -params-files             comma separated params files to load (default: ~/.sigurlx/params.json)\n"
+		h += "  -rules                    YAML rules file of extra categories, params, secrets and DOM patterns\n"
+		h += "  -param-source             include the rule id and file of matched common vuln params\n"
+
+		h += "\nPROBE OPTIONS:\n"
+		h += "  -case-insensitive         match DOM and secret patterns case-insensitively\n"
+		h += "  -crlf                     probe params for CRLF injection into response headers\n"
+		h += "  -csp                      flag missing or permissive Content-Security-Policy on html pages\n"
+		h += "  -debug-disclosure         look for stack traces and debug pages in responses\n"
+		h += "  -decode-passes            decode param values up to this many more times (e.g %2527)\n"
+		h += "  -dirlisting               detect directory listings on directory-like URLs\n"
+		h += "  -dom                      look for DOM XSS sources and sinks in js files\n"
+		h += "  -dom-categories           comma separated DOM categories: source,eval,write,navigation,custom (default: all)\n"
+		h += "  -encoded-reflection       also look for base64 and URL encoded reflections\n"
+		h += "  -force-checks             run body and param checks regardless of category\n"
+		h += "  -fragment-params          also analyze params in the URL fragment (e.g #/search?q=)\n"
+		h += "  -graphql                  probe graphql endpoints for introspection\n"
+		h += "  -handler-payloads         test reflected params for attribute event handler injection\n"
+		h += "  -host-probe               look for spoofed Host headers reflected in the body or redirect\n"
+		h += "  -hpp                      probe how duplicated params are handled (HPP)\n"
+		h += "  -inline-handlers          extract inline event handlers (e.g onclick) and javascript: URLs of html pages\n"
+		h += "  -jwt                      decode JWTs in params and flag none/weak algs, expiry and sensitive claims\n"
+		h += "  -links                    extract href/src/action links of html pages\n"
+		h += "  -matrix-params            also analyze matrix params in the path (e.g /a;jsessionid=x)\n"
+		h += "  -max-params               max params tested per URL, risky ones first (default: all)\n"
+		h += "  -method-probe             probe allowed methods and method override headers\n"
+		h += "  -mixed-content            report http:// subresources of https html pages\n"
+		h += "  -open-redirect            probe params for open redirects, incl. javascript: and data: targets\n"
+		h += "  -path-reflection          probe for reflection of the URL path\n"
+		h += "  -payload-prefix           string to pad reflection payloads with at the start\n"
+		h += "  -payload-suffix           string to pad reflection payloads with at the end\n"
+		h += "  -reflect-cache            test reflection once per host, path and param names\n"
+		h += "  -reflect-max-size         skip reflection tests if the body is larger, in bytes (default: no limit)\n"
+		h += "  -reflect-params           comma separated params to test for reflection (default: all)\n"
+		h += "  -reflect-types            comma separated content types to test reflection on (default: text/html,application/*,text/*)\n"
+		h += "  -scheme-diff              request the http version of https URLs and flag different answers\n"
+		h += "  -secrets                  look for API keys, tokens and private keys in responses\n"
+		h += "  -security-headers         flag html pages missing HSTS, X-Frame-Options or X-Content-Type-Options\n"
+		h += "  -sqli                     probe params for SQL errors and boolean based differences\n"
+		h += "  -sri                      check html pages for third party resources without SRI\n"
+		h += "  -swagger                  extract endpoints and params from swagger/openapi specs\n"
+		h += "  -vcs                      probe each host once for exposed .git, .svn, .env, e.t.c\n"
+		h += "  -verify                   confirm reflected characters with a second, unique token\n"
 
 		h += "\nHTTP OPTIONS:\n"
+		h += "  -body                     body to send with the main request, POST unless -jsonl sets a method\n"
+		h += "  -cache-bust               send no-cache headers and a random sigurlxcb param to bypass caches\n"
+		h += "  -cert                     client certificate file for mutual TLS\n"
+		h += "  -key                      client certificate key file for mutual TLS\n"
+		h += "  -content-type             Content-Type of the -body (e.g application/json)\n"
+		h += "  -conditional-cache        file to keep ETag/Last-Modified in for conditional re-scans\n"
+		h += "  -connect-to               connect to this host[:port] instead of the URL host, keeping Host and SNI\n"
 		h += "  -delay                    delay between requests (default: 100ms)\n"
+		h += "  -dns-cache-ttl            cache DNS lookups for this many seconds (default: disabled)\n"
 		h += "  -follow-redirects         follow redirects (default: false)\n"
 		h += "  -follow-host-redirects    follow internal redirects i.e, same host redirects (default: false)\n"
-		h += "  -http-proxy               HTTP Proxy URL\n"
+		h += "  -header-env               comma separated header=ENV_VAR pairs to read header values from\n"
+		h += "  -http-proxy               HTTP Proxy URL, or comma separated URLs to rotate through\n"
+		h += "  -proxy-rotation           how requests pick from -http-proxy URLs: round-robin or random (default: round-robin)\n"
+		h += "  -http10                   send HTTP/1.0 requests, one connection each, for legacy servers\n"
+		h += "  -idle-timeout             idle keep-alive connection timeout (default: 90s)\n"
+		h += "  -keep-alive               TCP keep-alive period (default: 30s)\n"
+		h += "  -local-addr               local source IP to send requests from\n"
+		h += "  -probe-scheme             try https then http for inputs without a scheme\n"
+		h += "  -raw-headers              file of headers to send in its order and casing, \"Name: value\" or \"Name\" lines\n"
+		h += "  -response-cache           directory to cache responses in and reuse them from on re-runs\n"
+		h += "  -response-cache-ttl       refetch cached responses older than this many seconds (default: never)\n"
+		h += "  -sni                      TLS SNI to send instead of the URL host\n"
+		h += "  -stream-size              stream larger bodies through DOM/secret scans, other checks only see this many bytes (default: off)\n"
+		h += "  -template                 YAML request template for the main request of each URL\n"
 		h += "  -timeout                  HTTP request timeout (default: 10s)\n"
 		h += "  -UA                       HTTP user agent\n"
 
 		h += "\nOUTPUT OPTIONS:\n"
 		h += "  -nC                       no color mode\n"
 		h += "  -oJ                       JSON output file (default: ./sigurlx.json)\n"
+		h += "  -oD                       directory to write a JSON file per host to\n"
+		h += "  -oS                       SARIF output file\n"
+		h += "  -oH                       responsive hosts output file\n"
+		h += "  -oL                       discovered links output file, to feed back as input\n"
+		h += "  -oN                       directory to write nuclei templates of reflected params to\n"
+		h += "  -oP                       param frequency CSV output file\n"
+		h += "  -oU                       unique findings JSON output file, collapsed across URLs with a count\n"
+		h += "  -oE                       endpoint map JSON output file of the params, methods and status codes per path\n"
+		h += "  -findings-only            only output URLs with findings\n"
+		h += "  -mc                       comma separated status codes to output (default: all)\n"
+		h += "  -mr                       mark results whose body matches this regex (body_match)\n"
+		h += "  -fr                       drop results whose body matches this regex (e.g soft-404s)\n"
+		h += "  -raw                      include raw HTTP request/response of findings\n"
+		h += "  -fuzz                     only print reflecting URLs with the param value set to FUZZ\n"
+		h += "  -json                     print results to stdout as JSON lines\n"
+		h += "  -pretty-json              indent JSON printed with -json\n"
+		h += "  -full-json                keep empty fields in the JSON output\n"
+		h += "  -fields                   comma separated JSON fields to output, in this order (default: all)\n"
+		h += "  -stats                    print a category and status histogram to stderr at the end\n"
 		h += "  -v                        verbose mode\n"
 
 		fmt.Fprintf(os.Stderr, h)
 	}
 
 	flag.Parse()
+
+	if co.tags != "" {
+		ro.Tags = strings.Split(co.tags, ",")
+	}
+
+	if co.paramsFiles != "" {
+		ro.ParamsFiles = strings.Split(co.paramsFiles, ",")
+	}
+
+	if co.reflect != "" {
+		for _, param := range strings.Split(co.reflect, ",") {
+			if param = strings.TrimSpace(param); param != "" {
+				ro.ReflectParams = append(ro.ReflectParams, param)
+			}
+		}
+	}
+
+	if co.DOMGroups != "" {
+		ro.DOMCategories = strings.Split(co.DOMGroups, ",")
+	}
+
+	if co.proxies != "" {
+		ro.HTTPProxies = strings.Split(co.proxies, ",")
+	}
+
+	if co.fields != "" {
+		ro.OutputFields = strings.Split(co.fields, ",")
+	}
+
+	if co.ignoreParams != "" {
+		ro.IgnoreParams = strings.Split(co.ignoreParams, ",")
+	}
+
+	if co.reflectTypes != "" {
+		ro.ReflectContentTypes = strings.Split(co.reflectTypes, ",")
+	}
+
+	if co.scope != "" {
+		ro.Scope = strings.Split(co.scope, ",")
+	}
+
+	if co.matchStatus != "" {
+		for _, code := range strings.Split(co.matchStatus, ",") {
+			statusCode, err := strconv.Atoi(strings.TrimSpace(code))
+			if err != nil {
+				log.Fatalln(fmt.Errorf("invalid status code: %s", code))
+			}
+
+			ro.MatchStatus = append(ro.MatchStatus, statusCode)
+		}
+	}
+
+	if co.headerEnv != "" {
+		ro.HeaderEnv = make(map[string]string)
+
+		for _, pair := range strings.Split(co.headerEnv, ",") {
+			tokens := strings.SplitN(pair, "=", 2)
+			if len(tokens) != 2 {
+				log.Fatalln(fmt.Errorf("invalid header-env pair: %s", pair))
+			}
+
+			ro.HeaderEnv[tokens[0]] = tokens[1]
+		}
+	}
+
+	ro.Delay = co.delay
+	ro.Threads = co.threads
 	ro.Parse()
 
 	au = aurora.NewAurora(!co.noColor)
@@ -106,81 +413,360 @@ func main() {
 		os.Exit(0)
 	}
 
-	URLs := make(chan string, co.threads)
+	var include, exclude *regexp.Regexp
 
-	go func() {
-		defer close(URLs)
+	if co.include != "" {
+		var err error
+
+		if include, err = regexp.Compile(co.include); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.exclude != "" {
+		var err error
+
+		if exclude, err = regexp.Compile(co.exclude); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.bodyMatch != "" {
+		var err error
+
+		if ro.MatchBodyRegex, err = regexp.Compile(co.bodyMatch); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.bodyFilter != "" {
+		var err error
 
-		var scanner *bufio.Scanner
+		if ro.FilterBodyRegex, err = regexp.Compile(co.bodyFilter); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.template != "" {
+		var err error
 
-		if co.URLs == "-" {
-			if !gos.HasStdin() {
-				log.Fatalln(errors.New("no stdin"))
+		if ro.RequestTemplate, err = sigurlx.LoadRequestTemplate(co.template); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.rawHeaders != "" {
+		headers, err := ioutil.ReadFile(co.rawHeaders)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		for _, line := range strings.Split(string(headers), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				ro.RawHeaders = append(ro.RawHeaders, line)
 			}
+		}
+	}
 
-			scanner = bufio.NewScanner(os.Stdin)
-		} else {
-			openedFile, err := os.Open(co.URLs)
-			if err != nil {
-				log.Fatalln(err)
+	var output, processed sigurlx.Results
+
+	ro.OnResult = func(results sigurlx.Result) {
+		if co.stats {
+			processed = append(processed, results)
+		}
+
+		if results.Error != "" {
+			if co.JSON || co.fuzz {
+				fmt.Fprintln(os.Stderr, au.BrightRed(" -"), results.URL, au.BrightRed("...failed!"))
+			} else {
+				fmt.Println(au.BrightRed(" -"), results.URL, au.BrightRed("...failed!"))
 			}
-			defer openedFile.Close()
 
-			scanner = bufio.NewScanner(openedFile)
+			if co.verbose {
+				fmt.Fprintf(os.Stderr, results.Error+"\n")
+			}
+
+			output = append(output, results)
+
+			return
 		}
 
-		for scanner.Scan() {
-			if scanner.Text() != "" {
-				URLs <- scanner.Text()
+		if co.findingsOnly && !results.HasFindings() {
+			return
+		}
+
+		if co.fuzz {
+			for _, URL := range sigurlx.FuzzURLs(results) {
+				fmt.Println(URL)
+			}
+		} else if co.JSON {
+			write := sigurlx.WriteResult
+
+			switch {
+			case len(ro.OutputFields) > 0:
+				write = func(w io.Writer, result sigurlx.Result) error {
+					return sigurlx.WriteResultFields(w, result, ro.OutputFields, ro.FullJSON, ro.PrettyJSON)
+				}
+			case ro.FullJSON && ro.PrettyJSON:
+				write = sigurlx.WriteResultFullIndented
+			case ro.FullJSON:
+				write = sigurlx.WriteResultFull
+			case ro.PrettyJSON:
+				write = sigurlx.WriteResultIndented
 			}
+
+			if err := write(os.Stdout, results); err != nil {
+				log.Fatalln(err)
+			}
+		} else {
+			fmt.Println(au.BrightGreen(" +"), results.URL, au.BrightGreen("...done!"))
 		}
 
-		if scanner.Err() != nil {
-			log.Fatalln(scanner.Err())
+		output = append(output, results)
+	}
+
+	runner, err := sigurlx.New(&ro)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if co.responses != "" {
+		if _, err := runner.AnalyzeDirectory(co.responses); err != nil {
+			log.Fatalln(err)
 		}
-	}()
+	}
+
+	URLs := make(chan sigurlx.RequestSpec, co.threads)
+
+	go func() {
+		defer close(URLs)
 
-	mutex := &sync.Mutex{}
-	wg := &sync.WaitGroup{}
+		var specs []sigurlx.RequestSpec
 
-	var output sigurlx.Results
+		enqueue := func(spec sigurlx.RequestSpec) {
+			if !sigurlx.MatchURL(spec.URL, include, exclude) {
+				return
+			}
 
-	for i := 0; i < co.threads; i++ {
-		wg.Add(1)
+			// shuffling needs the whole list, so hold the urls back until read
+			if ro.Shuffle {
+				specs = append(specs, spec)
 
-		time.Sleep(time.Duration(co.delay) * time.Millisecond)
+				return
+			}
 
-		go func() {
-			defer wg.Done()
+			URLs <- spec
+		}
 
-			runner, err := sigurlx.New(&ro)
+		if co.sitemap != "" {
+			sitemapURLs, err := runner.FetchSitemap(co.sitemap)
 			if err != nil {
 				log.Fatalln(err)
 			}
 
-			for URL := range URLs {
-				results, err := runner.Process(URL)
+			for _, URL := range sitemapURLs {
+				enqueue(sigurlx.RequestSpec{URL: URL})
+			}
+		}
+
+		if co.URLs != "" {
+			var input io.Reader
+
+			if co.URLs == "-" {
+				if !gos.HasStdin() {
+					log.Fatalln(errors.New("no stdin"))
+				}
+
+				input = os.Stdin
+			} else {
+				openedFile, err := os.Open(co.URLs)
 				if err != nil {
-					fmt.Println(au.BrightRed(" -"), results.URL, au.BrightRed("...failed!"))
+					log.Fatalln(err)
+				}
+				defer openedFile.Close()
 
-					if co.verbose {
-						fmt.Fprintf(os.Stderr, err.Error()+"\n")
-					}
+				input = openedFile
+			}
 
+			input, err := decompress(input, strings.HasSuffix(co.URLs, ".gz"))
+			if err != nil {
+				log.Fatalln(err)
+			}
+
+			scanner := bufio.NewScanner(input)
+
+			for scanner.Scan() {
+				if scanner.Text() == "" {
 					continue
 				}
 
-				mutex.Lock()
-				fmt.Println(au.BrightGreen(" +"), results.URL, au.BrightGreen("...done!"))
-				output = append(output, results)
-				mutex.Unlock()
+				spec := sigurlx.RequestSpec{URL: scanner.Text()}
+
+				if co.JSONL {
+					if err := json.Unmarshal(scanner.Bytes(), &spec); err != nil {
+						fmt.Fprintln(os.Stderr, au.BrightRed(" -"), scanner.Text(), au.BrightRed("...invalid!"))
+
+						continue
+					}
+				}
+
+				enqueue(spec)
+			}
+
+			if scanner.Err() != nil {
+				log.Fatalln(scanner.Err())
 			}
-		}()
+		}
+
+		sigurlx.ShuffleRequests(specs)
+
+		for _, spec := range specs {
+			URLs <- spec
+		}
+	}()
+
+	runner.ProcessStream(URLs)
+
+	if co.stats {
+		fmt.Fprint(os.Stderr, "\n", sigurlx.Summarize(processed).Histogram())
+	}
+
+	skipped := runner.SkippedPerCategory()
+
+	categories := make([]string, 0, len(skipped))
+
+	for category := range skipped {
+		categories = append(categories, category)
 	}
 
-	wg.Wait()
+	sort.Strings(categories)
+
+	for _, category := range categories {
+		fmt.Fprintln(os.Stderr, "[", au.BrightYellow("WRN"), "] skipped", skipped[category], category, "urls over -max-per-category")
+	}
+
+	if invalid := runner.InvalidURLs(); len(invalid) > 0 {
+		fmt.Fprintln(os.Stderr, "[", au.BrightYellow("WRN"), "] skipped", len(invalid), "unparseable urls")
+	}
 
-	if err := output.SaveToJSON(co.output); err != nil {
+	if err := runner.SaveValidators(); err != nil {
 		log.Fatalln(err)
 	}
+
+	save := output.SaveToJSON
+
+	switch {
+	case len(ro.OutputFields) > 0:
+		save = func(PATH string) error {
+			return output.SaveToFieldsJSON(PATH, ro.OutputFields, ro.FullJSON)
+		}
+	case ro.FullJSON:
+		save = output.SaveToFullJSON
+	}
+
+	if err := save(co.output); err != nil {
+		log.Fatalln(err)
+	}
+
+	if co.byHost != "" {
+		if err := output.SaveByHost(co.byHost, ro.OutputFields, ro.FullJSON); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.SARIF != "" {
+		file, err := os.Create(co.SARIF)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		defer file.Close()
+
+		if err := sigurlx.WriteSARIF(file, output); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.unique != "" {
+		file, err := os.Create(co.unique)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		defer file.Close()
+
+		if err := sigurlx.WriteUniqueFindings(file, sigurlx.UniqueFindings(output)); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.endpoints != "" {
+		file, err := os.Create(co.endpoints)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		defer file.Close()
+
+		if err := sigurlx.WriteEndpointMap(file, sigurlx.EndpointMap(output)); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.paramStats != "" {
+		file, err := os.Create(co.paramStats)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		defer file.Close()
+
+		if err := sigurlx.WriteStatsCSV(file, sigurlx.ParamStats(output)); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.hosts != "" {
+		var hosts string
+
+		for _, host := range sigurlx.ResponsiveHosts(output) {
+			hosts += host + "\n"
+		}
+
+		if err := ioutil.WriteFile(co.hosts, []byte(hosts), 0644); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.links != "" {
+		var links string
+
+		for _, link := range sigurlx.CollectLinks(output) {
+			links += link + "\n"
+		}
+
+		if err := ioutil.WriteFile(co.links, []byte(links), 0644); err != nil {
+			log.Fatalln(err)
+		}
+	}
+
+	if co.nuclei != "" {
+		if err := runner.WriteNucleiTemplates(co.nuclei, output); err != nil {
+			log.Fatalln(err)
+		}
+	}
+}
+
+// decompress transparently gunzips the input when it starts with the gzip
+// magic bytes or the file name says it is gzipped.
+func decompress(input io.Reader, gz bool) (io.Reader, error) {
+	reader := bufio.NewReader(input)
+
+	magic, _ := reader.Peek(2)
+
+	if gz || bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+		return gzip.NewReader(reader)
+	}
+
+	return reader, nil
 }
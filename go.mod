@@ -5,4 +5,7 @@ go 1.15
 require (
 	github.com/drsigned/gos v1.2.0
 	github.com/logrusorgru/aurora/v3 v3.0.0
+	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
+	golang.org/x/text v0.3.3
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -0,0 +1,161 @@
+package sigurlx
+
+import (
+	"crypto/tls"
+	"net/url"
+	"strings"
+)
+
+func (sigurlx *Sigurlx) AnalyzeBody(URL string, res Response) (result Result, err error) {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return result, err
+	}
+
+	result.URL = parsedURL.String()
+	result.Host = parsedURL.Host
+
+	var categoryMatch string
+
+	if result.Category, categoryMatch, err = sigurlx.categorize(URL); err != nil {
+		return result, err
+	}
+
+	if sigurlx.Options.CategoryMatch {
+		result.CategoryMatch = categoryMatch
+	}
+
+	result.ThirdParty = sigurlx.isThirdParty(parsedURL)
+
+	contentType := strings.Join(res.Headers["Content-Type"], ";")
+	if contentType == "" {
+		contentType = res.ContentType
+	}
+
+	res.Body = decodeBody(res.Body, contentType)
+
+	if err = sigurlx.analyzeResponse(parsedURL, res, &result); err != nil {
+		return result, err
+	}
+
+	// risky params need no request, so they are part of offline analysis too
+	if query, err := sigurlx.getQuery(parsedURL); err == nil {
+		result.Params = listParams(query, "query")
+
+		if sigurlx.Options.MatrixParams {
+			matrixQuery := getMatrixQuery(parsedURL.EscapedPath())
+
+			result.Params = append(result.Params, listParams(matrixQuery, "matrix")...)
+
+			for name, values := range matrixQuery {
+				query[name] = append(query[name], values...)
+			}
+		}
+
+		if sigurlx.Options.JWT && sigurlx.runCheck("jwt", result.Category, parsedURL, true) {
+			if result.JWTFindings, err = sigurlx.JWTProbe(query); err != nil {
+				return result, err
+			}
+		}
+
+		if len(query) > 0 && sigurlx.runCheck("common_vuln_params", result.Category, parsedURL, sigurlx.shouldTestParams(result.Category)) {
+			if result.CommonVulnParams, err = sigurlx.CommonVulnParamsProbe(query); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	result.Score = sigurlx.score(result)
+
+	return result, nil
+}
+
+func (sigurlx *Sigurlx) analyzeResponse(parsedURL *url.URL, res Response, result *Result) (err error) {
+	result.StatusCode = res.StatusCode
+	result.ContentType = res.ContentType
+	result.ContentLength = res.ContentLength
+	result.BodyTruncated = res.scan != nil
+	result.RedirectLocation = res.RedirectLocation
+
+	if sigurlx.Options.ExtendedCategories {
+		result.Type = classify(parsedURL, result.Category, res)
+	}
+
+	if res.TLS != nil {
+		result.TLSVersion = tlsVersionName(res.TLS.Version)
+		result.TLSCipherSuite = tls.CipherSuiteName(res.TLS.CipherSuite)
+		result.WeakTLS = isWeakTLS(res.TLS)
+	}
+
+	if !sigurlx.shouldAnalyzeBody(result.Category) {
+		return nil
+	}
+
+	category := result.Category
+
+	if sigurlx.Options.DOM && sigurlx.runCheck("dom", category, parsedURL, category == "js" || sigurlx.Options.ForceChecks) {
+		if result.DOM, err = sigurlx.DOMProbe(res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.Secrets && sigurlx.runCheck("secrets", category, parsedURL, true) {
+		if result.Secrets, err = sigurlx.SecretsProbe(res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.DebugDisclosure && sigurlx.runCheck("debug_disclosure", category, parsedURL, true) {
+		if result.DebugDisclosure, err = sigurlx.DebugDisclosureProbe(res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.DirectoryListing && isDirectory(parsedURL) && sigurlx.runCheck("directory_listing", category, parsedURL, true) {
+		if result.DirectoryListing, result.ListedEntries, err = sigurlx.DirectoryListingProbe(parsedURL, res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.CSP && isHTML(res) && sigurlx.runCheck("csp", category, parsedURL, true) {
+		if result.CSPIssues, err = sigurlx.CSPProbe(res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.SecurityHeaders && isHTML(res) && sigurlx.runCheck("security_headers", category, parsedURL, true) {
+		if result.MissingHeaders, err = sigurlx.MissingHeadersProbe(parsedURL, res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.SRI && isHTML(res) && sigurlx.runCheck("sri", category, parsedURL, true) {
+		if result.MissingSRI, err = sigurlx.MissingSRIProbe(parsedURL, res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.InlineHandlers && isHTML(res) && sigurlx.runCheck("inline_handlers", category, parsedURL, true) {
+		if result.InlineHandlers, err = sigurlx.InlineHandlersProbe(res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.MixedContent && isHTML(res) && sigurlx.runCheck("mixed_content", category, parsedURL, true) {
+		if result.MixedContent, err = sigurlx.MixedContentProbe(parsedURL, res); err != nil {
+			return err
+		}
+	}
+
+	if sigurlx.Options.Links && isHTML(res) && sigurlx.runCheck("links", category, parsedURL, true) {
+		if result.Links, err = sigurlx.LinksProbe(parsedURL, res); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isHTML(res Response) bool {
+	return strings.Contains(res.ContentType, "html")
+}
@@ -0,0 +1,78 @@
+package sigurlx
+
+import "net/http"
+
+type AuthDiff struct {
+	URL                   string `json:"url,omitempty"`
+	Access                string `json:"access,omitempty"`
+	AuthedStatusCode      int    `json:"authed_status_code,omitempty"`
+	UnauthedStatusCode    int    `json:"unauthed_status_code,omitempty"`
+	AuthedContentLength   int    `json:"authed_content_length,omitempty"`
+	UnauthedContentLength int    `json:"unauthed_content_length,omitempty"`
+}
+
+// DiffAuth compares the results of an authenticated and an unauthenticated
+// scan of the same urls. A url is:
+//
+//	auth-required          if it is denied or redirected without a session
+//	public                 if it is served the same with and without one
+//	auth-bypass-candidate  if its content depends on the session, yet it is
+//	                       still served without one
+//
+// URLs missing from either set, or not served with a session, are skipped.
+func DiffAuth(authed, unauthed Results) []AuthDiff {
+	var diffs []AuthDiff
+
+	index := make(map[string]Result)
+
+	for _, result := range unauthed {
+		index[result.URL] = result
+	}
+
+	for _, a := range authed {
+		u, ok := index[a.URL]
+		if !ok || !isSuccess(a.StatusCode) || u.ErrorKind != "" {
+			continue
+		}
+
+		diff := AuthDiff{
+			URL:                   a.URL,
+			AuthedStatusCode:      a.StatusCode,
+			UnauthedStatusCode:    u.StatusCode,
+			AuthedContentLength:   a.ContentLength,
+			UnauthedContentLength: u.ContentLength,
+		}
+
+		switch {
+		case !isSuccess(u.StatusCode):
+			diff.Access = "auth-required"
+		case similarLength(a.ContentLength, u.ContentLength):
+			diff.Access = "public"
+		default:
+			diff.Access = "auth-bypass-candidate"
+		}
+
+		diffs = append(diffs, diff)
+	}
+
+	return diffs
+}
+
+func isSuccess(statusCode int) bool {
+	return statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices
+}
+
+// similarLength allows for small dynamic parts like tokens and timestamps.
+func similarLength(a, b int) bool {
+	difference := a - b
+	if difference < 0 {
+		difference = -difference
+	}
+
+	larger := a
+	if b > larger {
+		larger = b
+	}
+
+	return difference <= larger/20
+}
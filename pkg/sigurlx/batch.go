@@ -0,0 +1,124 @@
+package sigurlx
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+func (sigurlx *Sigurlx) ProcessAll(URLs []string) Results {
+	specs := make([]RequestSpec, len(URLs))
+
+	for i, URL := range URLs {
+		specs[i] = RequestSpec{URL: URL}
+	}
+
+	return sigurlx.ProcessRequests(specs)
+}
+
+func (sigurlx *Sigurlx) ProcessRequests(specs []RequestSpec) Results {
+	if sigurlx.Options.Shuffle {
+		ShuffleRequests(specs)
+	}
+
+	queue := make(chan RequestSpec, sigurlx.Options.Threads)
+
+	go func() {
+		defer close(queue)
+
+		for _, spec := range specs {
+			queue <- spec
+		}
+	}()
+
+	var results Results
+
+	sigurlx.processStream(queue, func(result Result) {
+		results = append(results, result)
+	})
+
+	return results
+}
+
+// ProcessStream processes specs as they are received until the channel is
+// closed. Results are only handed to Options.OnResult.
+func (sigurlx *Sigurlx) ProcessStream(specs <-chan RequestSpec) {
+	sigurlx.processStream(specs, nil)
+}
+
+func (sigurlx *Sigurlx) processStream(specs <-chan RequestSpec, collect func(Result)) {
+	mutex := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+
+	// the workers share everything but the context, which bounds the run
+	worker := *sigurlx
+
+	if sigurlx.Options.MaxRunTime > 0 {
+		var cancel context.CancelFunc
+
+		worker.ctx, cancel = context.WithTimeout(sigurlx.ctx, time.Duration(sigurlx.Options.MaxRunTime)*time.Second)
+		defer cancel()
+	}
+
+	// Options.Parse sets the default, New doesn't
+	threads := sigurlx.Options.Threads
+	if threads <= 0 {
+		threads = 20
+	}
+
+	for i := 0; i < threads; i++ {
+		wg.Add(1)
+
+		time.Sleep(time.Duration(sigurlx.Options.Delay) * time.Millisecond)
+
+		go func() {
+			defer wg.Done()
+
+			for spec := range specs {
+				// keep draining so the sender isn't blocked once time is up
+				if worker.ctx.Err() != nil {
+					continue
+				}
+
+				result, err := worker.ProcessRequest(spec)
+				if errors.Is(err, ErrFiltered) {
+					continue
+				}
+
+				// requests cut short by the deadline are dropped, not reported
+				if err != nil && worker.ctx.Err() != nil {
+					continue
+				}
+
+				if sigurlx.Options.Lenient && ErrorKind(err) == "parse" {
+					sigurlx.invalid.add(spec.URL, err)
+
+					continue
+				}
+
+				// calls are serialized so hooks don't need their own locking
+				mutex.Lock()
+				if collect != nil {
+					collect(result)
+				}
+
+				if sigurlx.Options.OnResult != nil {
+					sigurlx.Options.OnResult(result)
+				}
+				mutex.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func ShuffleRequests(specs []RequestSpec) {
+	random := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	random.Shuffle(len(specs), func(i, j int) {
+		specs[i], specs[j] = specs[j], specs[i]
+	})
+}
@@ -0,0 +1,29 @@
+package sigurlx
+
+import "strings"
+
+var cdnCacheHeaders = []string{"X-Cache", "Cf-Cache-Status", "X-Varnish", "X-Served-By", "X-Cache-Hits", "X-Proxy-Cache", "Akamai-Cache-Status"}
+
+func isCacheable(res Response) bool {
+	cacheControl := strings.ToLower(strings.Join(res.Headers["Cache-Control"], ","))
+
+	if strings.Contains(cacheControl, "no-store") || strings.Contains(cacheControl, "private") {
+		return false
+	}
+
+	if strings.Contains(cacheControl, "public") || strings.Contains(cacheControl, "s-maxage") {
+		return true
+	}
+
+	if _, ok := res.Headers["Age"]; ok {
+		return true
+	}
+
+	for _, header := range cdnCacheHeaders {
+		if _, ok := res.Headers[header]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,52 +1,62 @@
 package sigurlx
 
+import "regexp"
+
 func (sigurlx *Sigurlx) initCategories() {
+	sigurlx.APIDOCRegex, _ = newRegex(`(?mi).*?/(swagger|openapi|api-docs|swagger-ui|swagger-resources)(\.(json|yaml|yml|html))?/?(\?.*?|)$`)
 	sigurlx.JSRegex, _ = newRegex(`(?m).*?\.(js)(\?.*?|)$`)
 	sigurlx.DOCRegex, _ = newRegex(`(?m).*?\.(pdf|xlsx|doc|docx|txt)(\?.*?|)$`)
 	sigurlx.DATARegex, _ = newRegex(`(?m).*?\.(json|xml|csv)(\?.*?|)$`)
 	sigurlx.STYLERegex, _ = newRegex(`(?m).*?\.(css)(\?.*?|)$`)
 	sigurlx.MEDIARegex, _ = newRegex(`(?m).*?\.(jpg|jpeg|png|ico|svg|gif|webp|mp3|mp4|woff|woff2|ttf|eot|tif|tiff)(\?.*?|)$`)
 	sigurlx.ARCHIVERegex, _ = newRegex(`(?m).*?\.(zip|tar|tar\.gz)(\?.*?|)$`)
+	sigurlx.GRAPHQLRegex, _ = newRegex(`(?m).*?/graphql(/v[0-9]+)?/?(\?.*?|)$`)
 }
 
-func (sigurlx *Sigurlx) categorize(URL string) (category string, err error) {
-	if match := sigurlx.JSRegex.MatchString(URL); match {
-		category = "js"
-	}
-
-	if category == "" {
-		if match := sigurlx.DOCRegex.MatchString(URL); match {
-			category = "doc"
+// categorize also returns what decided the category, for
+// Options.CategoryMatch: the regex along with the extension or path it
+// caught, e.g "MEDIARegex: svg", or "custom: <regex>" for rules categories.
+func (sigurlx *Sigurlx) categorize(URL string) (category, match string, err error) {
+	for _, custom := range sigurlx.customCategories {
+		if custom.regex.MatchString(URL) {
+			return custom.name, "custom: " + custom.regex.String(), nil
 		}
 	}
 
-	if category == "" {
-		if match := sigurlx.DATARegex.MatchString(URL); match {
-			category = "data"
-		}
+	builtins := []struct {
+		category string
+		name     string
+		regex    *regexp.Regexp
+	}{
+		{"apidoc", "APIDOCRegex", sigurlx.APIDOCRegex},
+		{"js", "JSRegex", sigurlx.JSRegex},
+		{"doc", "DOCRegex", sigurlx.DOCRegex},
+		{"data", "DATARegex", sigurlx.DATARegex},
+		{"style", "STYLERegex", sigurlx.STYLERegex},
+		{"media", "MEDIARegex", sigurlx.MEDIARegex},
+		{"archive", "ARCHIVERegex", sigurlx.ARCHIVERegex},
+		{"graphql", "GRAPHQLRegex", sigurlx.GRAPHQLRegex},
 	}
 
-	if category == "" {
-		if match := sigurlx.STYLERegex.MatchString(URL); match {
-			category = "style"
+	for _, builtin := range builtins {
+		submatch := builtin.regex.FindStringSubmatch(URL)
+		if submatch == nil {
+			continue
 		}
-	}
 
-	if category == "" {
-		if match := sigurlx.MEDIARegex.MatchString(URL); match {
-			category = "media"
-		}
-	}
+		match = builtin.name
 
-	if category == "" {
-		if match := sigurlx.ARCHIVERegex.MatchString(URL); match {
-			category = "archive"
+		// the first group is the extension, or the path, of the builtin ones
+		if len(submatch) > 1 && submatch[1] != "" {
+			match += ": " + submatch[1]
 		}
+
+		return builtin.category, match, nil
 	}
 
-	if category == "" {
-		category = "endpoint"
+	if query, err := getQuery(URL); err == nil && isGraphQLQuery(query) {
+		return "graphql", "graphql query", nil
 	}
 
-	return category, nil
+	return "endpoint", "", nil
 }
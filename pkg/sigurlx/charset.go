@@ -0,0 +1,44 @@
+package sigurlx
+
+import (
+	"regexp"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+)
+
+// only looked for in the first 1024 bytes, as DetermineEncoding does
+var metaCharsetRegex = regexp.MustCompile(`(?is)<meta[^>]*charset`)
+
+// decodeBody converts body to UTF-8 when a BOM, the Content-Type header or a
+// <meta charset> declares another encoding. Undeclared bodies are left as-is.
+func decodeBody(body []byte, contentType string) []byte {
+	if len(body) == 0 {
+		return body
+	}
+
+	e, name, certain := charset.DetermineEncoding(body, contentType)
+	if e == encoding.Nop || name == "utf-8" {
+		return body
+	}
+
+	// uncertain is either a <meta charset> or the windows-1252 fallback
+	if !certain && !metaCharsetRegex.Match(prescanned(body)) {
+		return body
+	}
+
+	decoded, err := e.NewDecoder().Bytes(body)
+	if err != nil {
+		return body
+	}
+
+	return decoded
+}
+
+func prescanned(body []byte) []byte {
+	if len(body) > 1024 {
+		return body[:1024]
+	}
+
+	return body
+}
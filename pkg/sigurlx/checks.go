@@ -0,0 +1,91 @@
+package sigurlx
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CheckGate limits a check to URLs of the given categories and to URLs with
+// one of the given params. Categories replace the ones a check runs on by
+// default, empty lists don't limit.
+type CheckGate struct {
+	Categories []string `yaml:"categories"`
+	Params     []string `yaml:"params"`
+}
+
+// Checks are the names that can be gated through Options.Checks or the
+// rules file. Gates only limit checks, those behind an option still need it.
+var Checks = []string{
+	"dom", "secrets", "debug_disclosure", "directory_listing", "csp", "security_headers", "sri", "inline_handlers", "mixed_content", "links",
+	"method_probe", "vcs", "host_probe", "scheme_diff", "jwt", "swagger", "graphql", "upload",
+	"path_reflection", "common_vuln_params", "reflection", "hpp", "crlf", "open_redirect", "sqli",
+}
+
+// categories whose bodies hold nothing worth analyzing
+var skipBodyCategories = map[string]bool{
+	"media":   true,
+	"archive": true,
+}
+
+// categories whose params are worth probing
+var paramCategories = map[string]bool{
+	"endpoint": true,
+}
+
+func (sigurlx *Sigurlx) shouldAnalyzeBody(category string) bool {
+	return sigurlx.Options.ForceChecks || !skipBodyCategories[category]
+}
+
+func (sigurlx *Sigurlx) shouldTestParams(category string) bool {
+	return sigurlx.Options.ForceChecks || paramCategories[category]
+}
+
+func (sigurlx *Sigurlx) initChecks() error {
+	sigurlx.checks = make(map[string]CheckGate)
+
+	return sigurlx.addChecks(sigurlx.Options.Checks)
+}
+
+func (sigurlx *Sigurlx) addChecks(gates map[string]CheckGate) error {
+	for check, gate := range gates {
+		if !containsString(Checks, check) {
+			return fmt.Errorf("unknown check: %s", check)
+		}
+
+		sigurlx.checks[check] = gate
+	}
+
+	return nil
+}
+
+// runCheck reports whether check should run on parsedURL, byDefault being
+// whether it would without a gate.
+func (sigurlx *Sigurlx) runCheck(check, category string, parsedURL *url.URL, byDefault bool) bool {
+	gate, ok := sigurlx.checks[check]
+	if !ok {
+		return byDefault
+	}
+
+	if len(gate.Categories) > 0 {
+		if !containsString(gate.Categories, category) {
+			return false
+		}
+	} else if !byDefault {
+		return false
+	}
+
+	if len(gate.Params) == 0 {
+		return true
+	}
+
+	for param := range parsedURL.Query() {
+		for _, name := range gate.Params {
+			if strings.EqualFold(param, name) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,36 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var apiPathRegex = regexp.MustCompile(`(?i)/(api|rest|graphql|v[0-9]+)(/|$)`)
+
+var staticCategories = map[string]bool{
+	"js":      true,
+	"style":   true,
+	"media":   true,
+	"archive": true,
+	"doc":     true,
+}
+
+func classify(parsedURL *url.URL, category string, res Response) string {
+	if staticCategories[category] {
+		return "static"
+	}
+
+	if category == "graphql" || category == "apidoc" || apiPathRegex.MatchString(parsedURL.Path) {
+		return "api"
+	}
+
+	switch {
+	case strings.Contains(res.ContentType, "json"), strings.Contains(res.ContentType, "xml"):
+		return "api"
+	case isHTML(res):
+		return "page"
+	}
+
+	return ""
+}
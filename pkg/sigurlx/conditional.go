@@ -0,0 +1,93 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+)
+
+type validator struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+func (sigurlx *Sigurlx) initValidators() error {
+	sigurlx.validators = make(map[string]validator)
+
+	if sigurlx.Options.ConditionalCache == "" {
+		return nil
+	}
+
+	raw, err := ioutil.ReadFile(sigurlx.Options.ConditionalCache)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return json.Unmarshal(raw, &sigurlx.validators)
+}
+
+func (sigurlx *Sigurlx) SaveValidators() error {
+	if sigurlx.Options.ConditionalCache == "" {
+		return nil
+	}
+
+	sigurlx.validatorsMutex.Lock()
+	defer sigurlx.validatorsMutex.Unlock()
+
+	JSON, err := json.Marshal(sigurlx.validators)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(sigurlx.Options.ConditionalCache, JSON, 0644)
+}
+
+func (sigurlx *Sigurlx) conditionalHeaders(URL string) map[string]string {
+	if sigurlx.Options.ConditionalCache == "" {
+		return nil
+	}
+
+	sigurlx.validatorsMutex.Lock()
+	defer sigurlx.validatorsMutex.Unlock()
+
+	v, ok := sigurlx.validators[URL]
+	if !ok {
+		return nil
+	}
+
+	headers := make(map[string]string)
+
+	if v.ETag != "" {
+		headers["If-None-Match"] = v.ETag
+	}
+
+	if v.LastModified != "" {
+		headers["If-Modified-Since"] = v.LastModified
+	}
+
+	return headers
+}
+
+func (sigurlx *Sigurlx) storeValidators(URL string, res Response) {
+	if sigurlx.Options.ConditionalCache == "" {
+		return
+	}
+
+	v := validator{
+		ETag:         res.GetHeaderPart("Etag", "\n"),
+		LastModified: res.GetHeaderPart("Last-Modified", "\n"),
+	}
+
+	if v.ETag == "" && v.LastModified == "" {
+		return
+	}
+
+	sigurlx.validatorsMutex.Lock()
+	defer sigurlx.validatorsMutex.Unlock()
+
+	sigurlx.validators[URL] = v
+}
@@ -0,0 +1,24 @@
+package sigurlx
+
+import (
+	"context"
+	"net"
+	"strings"
+)
+
+// connectTo dials address instead of where requests go, keeping their port
+// unless address has one, e.g to reach a virtual host on a given IP.
+func connectTo(dial dialContext, address string) dialContext {
+	return func(ctx context.Context, network, target string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			host = strings.Trim(address, "[]")
+
+			if _, port, err = net.SplitHostPort(target); err != nil {
+				return nil, err
+			}
+		}
+
+		return dial(ctx, network, net.JoinHostPort(host, port))
+	}
+}
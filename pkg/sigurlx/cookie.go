@@ -0,0 +1,68 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// setCookieReflections returns the tested params whose value comes back in
+// a Set-Cookie header of res, confirmed by appending a token to the value.
+func (sigurlx *Sigurlx) setCookieReflections(parsedURL *url.URL, query url.Values, res Response) []ReflectedParam {
+	var reflectedParams []ReflectedParam
+
+	if len(res.Headers["Set-Cookie"]) == 0 {
+		return reflectedParams
+	}
+
+	tested := sigurlx.testedParams(query)
+
+	params := make([]string, 0, len(query))
+
+	for param := range query {
+		if sigurlx.shouldReflect(param) && tested[param] && setCookieContains(res, query.Get(param)) {
+			params = append(params, param)
+		}
+	}
+
+	sort.Strings(params)
+
+	token := "aprefixasuffix"
+
+	for _, param := range params {
+		value := query.Get(param) + sigurlx.Options.PayloadPrefix + token + sigurlx.Options.PayloadSuffix
+
+		res, err := sigurlx.requestWithParam(parsedURL, param, value)
+		if err != nil || !setCookieContains(res, token) {
+			continue
+		}
+
+		reflectedParams = append(reflectedParams, ReflectedParam{
+			Param:     param,
+			Location:  "set-cookie",
+			Cacheable: isCacheable(res),
+			Raw:       res.Raw,
+		})
+	}
+
+	return reflectedParams
+}
+
+func setCookieContains(res Response, value string) bool {
+	if value == "" {
+		return false
+	}
+
+	for _, cookie := range res.Headers["Set-Cookie"] {
+		if strings.Contains(cookie, value) {
+			return true
+		}
+
+		// cookie values are often URL encoded
+		if strings.Contains(cookie, url.QueryEscape(value)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,61 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const crlfHeader = "Sigx"
+
+// CRLFProbe injects a header through each param. Context is "injected" when
+// the header came back on its own, or "reflected:<header>" when the payload
+// ended up in an existing header, e.g a Location built from the param.
+func (sigurlx *Sigurlx) CRLFProbe(parsedURL *url.URL, query url.Values) ([]ReflectedParam, error) {
+	var CRLFs []ReflectedParam
+
+	params := make([]string, 0, len(query))
+
+	for param := range sigurlx.testedParams(query) {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		res, err := sigurlx.requestWithParam(parsedURL, param, query.Get(param)+"\r\n"+crlfHeader+": 1")
+		if err != nil {
+			continue
+		}
+
+		if _, ok := res.Headers[crlfHeader]; ok {
+			CRLFs = append(CRLFs, ReflectedParam{Param: param, Context: "injected", Raw: res.Raw})
+
+			continue
+		}
+
+		headers := make([]string, 0, len(res.Headers))
+
+		for header := range res.Headers {
+			headers = append(headers, header)
+		}
+
+		sort.Strings(headers)
+
+		for _, header := range headers {
+			if hasCRLFPayload(strings.Join(res.Headers[header], " ")) {
+				CRLFs = append(CRLFs, ReflectedParam{Param: param, Context: "reflected:" + header, Raw: res.Raw})
+
+				break
+			}
+		}
+	}
+
+	return CRLFs, nil
+}
+
+// hasCRLFPayload reports whether a header value holds the payload unencoded,
+// a Location echoing the request URL holds it as %0D%0ASigx%3A+1, harmlessly.
+func hasCRLFPayload(value string) bool {
+	return strings.ContainsAny(value, "\r\n") || strings.Contains(strings.ToLower(value), strings.ToLower(crlfHeader+": 1"))
+}
@@ -0,0 +1,107 @@
+package sigurlx
+
+import (
+	"sort"
+	"strings"
+)
+
+// parseCSP splits a policy into directives and their lowercased sources.
+func parseCSP(policy string) map[string][]string {
+	directives := make(map[string][]string)
+
+	for _, directive := range strings.Split(policy, ";") {
+		tokens := strings.Fields(strings.ToLower(directive))
+		if len(tokens) == 0 {
+			continue
+		}
+
+		if _, ok := directives[tokens[0]]; !ok {
+			directives[tokens[0]] = tokens[1:]
+		}
+	}
+
+	return directives
+}
+
+// CSPProbe checks every policy on its own. Browsers enforce all of them, so
+// an issue is only reported when each policy has it.
+func (sigurlx *Sigurlx) CSPProbe(res Response) ([]string, error) {
+	var policies []string
+
+	// a header may also hold several policies separated by commas
+	for _, header := range res.Headers["Content-Security-Policy"] {
+		for _, policy := range strings.Split(header, ",") {
+			if strings.TrimSpace(policy) != "" {
+				policies = append(policies, policy)
+			}
+		}
+	}
+
+	if len(policies) == 0 {
+		return []string{"missing content-security-policy"}, nil
+	}
+
+	counts := make(map[string]int)
+
+	for _, policy := range policies {
+		for _, issue := range policyIssues(policy) {
+			counts[issue]++
+		}
+	}
+
+	var issues []string
+
+	for issue, count := range counts {
+		if count == len(policies) {
+			issues = append(issues, issue)
+		}
+	}
+
+	sort.Strings(issues)
+
+	return issues, nil
+}
+
+func policyIssues(policy string) []string {
+	var issues []string
+
+	directives := parseCSP(policy)
+
+	if _, ok := directives["default-src"]; !ok {
+		issues = append(issues, "missing default-src")
+	}
+
+	for _, directive := range []string{"script-src", "object-src", "default-src"} {
+		sources, ok := directives[directive]
+		if !ok {
+			continue
+		}
+
+		// nonces and hashes make browsers ignore 'unsafe-inline'
+		hasNonce := false
+
+		for _, source := range sources {
+			if strings.HasPrefix(source, "'nonce-") || strings.HasPrefix(source, "'sha") {
+				hasNonce = true
+			}
+		}
+
+		seen := make(map[string]bool)
+
+		for _, source := range sources {
+			switch {
+			case seen[source]:
+			case source == "'unsafe-inline'" && !hasNonce,
+				source == "'unsafe-eval'",
+				source == "*",
+				source == "data:",
+				source == "http:",
+				source == "https:":
+				seen[source] = true
+				issues = append(issues, directive+": "+source)
+			}
+		}
+	}
+
+	return issues
+}
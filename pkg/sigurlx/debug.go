@@ -0,0 +1,33 @@
+package sigurlx
+
+import (
+	"regexp"
+	"sort"
+)
+
+var debugDisclosureRegexes = map[string]*regexp.Regexp{
+	"symfony":      regexp.MustCompile(`(?i)(symfony\\component\\|sf-dump|symfony profiler)`),
+	"django":       regexp.MustCompile(`(?i)(you're seeing this error because you have <code>debug = true</code>|django\.core\.|traceback \(most recent call last\))`),
+	"rails":        regexp.MustCompile(`(?i)(action controller: exception caught|actionview::template::error|activerecord::[a-z]+error)`),
+	"spring":       regexp.MustCompile(`(?i)(whitelabel error page|org\.springframework\.[a-z.]+)`),
+	"java":         regexp.MustCompile(`(?m)^\s*at [a-zA-Z0-9_$.]+\([A-Za-z0-9_]+\.java:[0-9]+\)`),
+	"php":          regexp.MustCompile(`(?i)<b>(fatal error|warning|parse error|notice)</b>:.+ in <b>[^<]+</b> on line <b>[0-9]+</b>`),
+	"aspnet":       regexp.MustCompile(`(?i)(server error in '/' application|stack trace:</b>|system\.web\.httpexception)`),
+	"laravel":      regexp.MustCompile(`(?i)(whoops, looks like something went wrong|illuminate\\[a-z]+\\)`),
+	"unix path":    regexp.MustCompile(`(?:/var/www|/home/[a-z0-9_.-]+|/usr/(?:local|share)/[a-z0-9_.-]+|/opt/[a-z0-9_.-]+)/[^\s"'<>]+\.(?:php|py|rb|java|js|go)\b`),
+	"windows path": regexp.MustCompile(`(?i)\b[c-z]:\\(?:inetpub|windows|users|program files)[^\s"'<>]*`),
+}
+
+func (sigurlx *Sigurlx) DebugDisclosureProbe(res Response) ([]string, error) {
+	var disclosures []string
+
+	for name, regex := range debugDisclosureRegexes {
+		if match := regex.Find(res.Body); match != nil {
+			disclosures = append(disclosures, name+": "+string(match))
+		}
+	}
+
+	sort.Strings(disclosures)
+
+	return disclosures, nil
+}
@@ -0,0 +1,71 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type seenURLs struct {
+	mutex *sync.Mutex
+	keys  map[string]bool
+}
+
+// add reports whether key wasn't seen before.
+func (seen *seenURLs) add(key string) bool {
+	seen.mutex.Lock()
+	defer seen.mutex.Unlock()
+
+	if seen.keys[key] {
+		return false
+	}
+
+	seen.keys[key] = true
+
+	return true
+}
+
+// dedupKey canonicalizes parsedURL for Options.Dedup: the scheme and host are
+// lower cased, the params sorted and Options.IgnoreParams, e.g session or
+// csrf tokens, left out so that they don't make every URL unique.
+func (sigurlx *Sigurlx) dedupKey(parsedURL *url.URL) string {
+	query := parsedURL.Query()
+
+	for name := range query {
+		for _, ignored := range sigurlx.Options.IgnoreParams {
+			if strings.EqualFold(name, strings.TrimSpace(ignored)) {
+				query.Del(name)
+
+				break
+			}
+		}
+	}
+
+	names := make([]string, 0, len(query))
+
+	for name := range query {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	var params []string
+
+	for _, name := range names {
+		values := query[name]
+
+		sort.Strings(values)
+
+		for _, value := range values {
+			params = append(params, url.QueryEscape(name)+"="+url.QueryEscape(value))
+		}
+	}
+
+	path := parsedURL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.ToLower(parsedURL.Scheme) + "://" + strings.ToLower(parsedURL.Host) + path + "?" + strings.Join(params, "&")
+}
@@ -0,0 +1,43 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var directoryListingRegex = regexp.MustCompile(`(?i)(<title>Index of /|<h1>Index of /|\[To Parent Directory\]|<title>Directory listing for /)`)
+
+var hrefRegex = regexp.MustCompile(`(?i)href\s*=\s*["']([^"']+)["']`)
+
+func isDirectory(parsedURL *url.URL) bool {
+	return parsedURL.Path == "" || strings.HasSuffix(parsedURL.Path, "/")
+}
+
+// DirectoryListingProbe reports auto-index pages and returns the absolute
+// URLs of the entries they list.
+func (sigurlx *Sigurlx) DirectoryListingProbe(parsedURL *url.URL, res Response) (bool, []string, error) {
+	var entries []string
+
+	if !directoryListingRegex.Match(res.Body) {
+		return false, entries, nil
+	}
+
+	for _, match := range hrefRegex.FindAllSubmatch(res.Body, -1) {
+		href := string(match[1])
+
+		// skip the parent directory and the column sorting links
+		if strings.HasPrefix(href, "?") || strings.HasPrefix(href, "../") || href == "/" {
+			continue
+		}
+
+		entry, err := parsedURL.Parse(href)
+		if err != nil || entry.Host != parsedURL.Host || !strings.HasPrefix(entry.Path, parsedURL.Path) || entry.Path == parsedURL.Path {
+			continue
+		}
+
+		entries = append(entries, entry.String())
+	}
+
+	return true, entries, nil
+}
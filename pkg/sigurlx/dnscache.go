@@ -0,0 +1,79 @@
+package sigurlx
+
+import (
+	"context"
+	"net"
+	"sync"
+	"time"
+)
+
+type dialContext func(ctx context.Context, network, address string) (net.Conn, error)
+
+type dnsCacheEntry struct {
+	addrs   []string
+	expires time.Time
+}
+
+type dnsCache struct {
+	resolver *net.Resolver
+	TTL      time.Duration
+	mutex    sync.Mutex
+	entries  map[string]dnsCacheEntry
+}
+
+func newDNSCache(TTL time.Duration) *dnsCache {
+	return &dnsCache{
+		resolver: net.DefaultResolver,
+		TTL:      TTL,
+		entries:  make(map[string]dnsCacheEntry),
+	}
+}
+
+func (cache *dnsCache) lookup(ctx context.Context, host string) ([]string, error) {
+	cache.mutex.Lock()
+	entry, ok := cache.entries[host]
+	cache.mutex.Unlock()
+
+	if ok && time.Now().Before(entry.expires) {
+		return entry.addrs, nil
+	}
+
+	addrs, err := cache.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.mutex.Lock()
+	cache.entries[host] = dnsCacheEntry{addrs: addrs, expires: time.Now().Add(cache.TTL)}
+	cache.mutex.Unlock()
+
+	return addrs, nil
+}
+
+func (cache *dnsCache) wrap(dial dialContext) dialContext {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+
+		if net.ParseIP(host) != nil {
+			return dial(ctx, network, address)
+		}
+
+		addrs, err := cache.lookup(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+
+		var conn net.Conn
+
+		for _, addr := range addrs {
+			if conn, err = dial(ctx, network, net.JoinHostPort(addr, port)); err == nil {
+				return conn, nil
+			}
+		}
+
+		return nil, err
+	}
+}
@@ -0,0 +1,104 @@
+package sigurlx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DOMCategories are the groups DOM patterns are split into, each of which
+// can be toggled through Options.DOMCategories. Sources and sinks from the
+// rules file go into "source" and "custom".
+var DOMCategories = []string{"source", "eval", "write", "navigation", "custom"}
+
+var defaultDOMSources = []string{
+	`document\.(URL|documentURI|URLUnencoded|baseURI|cookie|referrer)`,
+	`location\.(href|search|hash|pathname)`,
+	`window\.name`,
+	`history\.(pushState|replaceState)`,
+	`(local|session)Storage`,
+}
+
+var defaultDOMSinks = map[string][]string{
+	"eval": {
+		`eval\(`,
+		`Function\(`,
+		`set(Timeout|Interval|Immediate)\(`,
+		`execScript\(`,
+	},
+	"write": {
+		`document\.(write|writeln)\(`,
+		`\.(inner|outer)HTML`,
+		`\.insertAdjacentHTML\(`,
+	},
+	"navigation": {
+		`location\.(assign|replace)\(`,
+	},
+}
+
+func (sigurlx *Sigurlx) initDOM() (err error) {
+	enabled := make(map[string]bool)
+
+	for _, category := range sigurlx.Options.DOMCategories {
+		if !containsString(DOMCategories, category) {
+			return fmt.Errorf("unknown DOM category: %s", category)
+		}
+
+		enabled[category] = true
+	}
+
+	patterns := map[string][]string{
+		"source": append(append([]string{}, defaultDOMSources...), sigurlx.domSources...),
+		"custom": sigurlx.domSinks,
+	}
+
+	for category, sinks := range defaultDOMSinks {
+		patterns[category] = sinks
+	}
+
+	var groups []string
+
+	for _, category := range DOMCategories {
+		if (len(enabled) > 0 && !enabled[category]) || len(patterns[category]) == 0 {
+			continue
+		}
+
+		groups = append(groups, `(?P<`+category+`>`+strings.Join(patterns[category], `|`)+`)`)
+	}
+
+	// an empty regex would match everywhere
+	if len(groups) == 0 {
+		sigurlx.DOMXSSRegex = nil
+
+		return nil
+	}
+
+	sigurlx.DOMXSSRegex, err = newRegex(caseInsensitive(strings.Join(groups, `|`), sigurlx.Options.CaseInsensitive))
+
+	return err
+}
+
+func (sigurlx *Sigurlx) DOMProbe(res Response) ([]string, error) {
+	if res.scan != nil {
+		return res.scan.DOM, nil
+	}
+
+	var DOM []string
+
+	if sigurlx.DOMXSSRegex == nil {
+		return DOM, nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, match := range sigurlx.DOMXSSRegex.FindAll(res.Body, -1) {
+		if !seen[string(match)] {
+			seen[string(match)] = true
+			DOM = append(DOM, string(match))
+		}
+	}
+
+	sort.Strings(DOM)
+
+	return DOM, nil
+}
@@ -0,0 +1,109 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// EndpointInfo is what a batch revealed of an endpoint.
+type EndpointInfo struct {
+	Params      []string `json:"params,omitempty"`
+	Methods     []string `json:"methods,omitempty"`
+	StatusCodes []int    `json:"status_codes,omitempty"`
+}
+
+// EndpointMap aggregates results by scheme, host and path into the params,
+// methods and status codes seen for each, swagger endpoints included, for a
+// map of the API surface behind a flat list of URLs.
+func EndpointMap(results Results) map[string]EndpointInfo {
+	endpoints := make(map[string]EndpointInfo)
+
+	add := func(key string, params, methods []string, statusCode int) {
+		endpoint := endpoints[key]
+
+		for _, param := range params {
+			if !containsString(endpoint.Params, param) {
+				endpoint.Params = append(endpoint.Params, param)
+			}
+		}
+
+		for _, method := range methods {
+			if method = strings.ToUpper(method); !containsString(endpoint.Methods, method) {
+				endpoint.Methods = append(endpoint.Methods, method)
+			}
+		}
+
+		if statusCode > 0 && !containsInt(endpoint.StatusCodes, statusCode) {
+			endpoint.StatusCodes = append(endpoint.StatusCodes, statusCode)
+		}
+
+		endpoints[key] = endpoint
+	}
+
+	for _, result := range results {
+		parsedURL, err := url.Parse(result.URL)
+		if err != nil {
+			continue
+		}
+
+		params := make([]string, 0, len(result.Params))
+
+		for _, param := range result.Params {
+			params = append(params, param.Name)
+		}
+
+		add(endpointKey(parsedURL), params, result.AllowedMethods, result.StatusCode)
+
+		for _, endpoint := range result.SwaggerEndpoints {
+			// url.Parse would escape the braces of templated paths
+			specURL := *parsedURL
+			specURL.Path, specURL.RawPath = endpoint.Path, ""
+
+			add(endpointKey(&specURL), endpoint.Params, []string{endpoint.Method}, 0)
+		}
+	}
+
+	for key, endpoint := range endpoints {
+		sort.Strings(endpoint.Params)
+		sort.Strings(endpoint.Methods)
+		sort.Ints(endpoint.StatusCodes)
+
+		endpoints[key] = endpoint
+	}
+
+	return endpoints
+}
+
+func endpointKey(parsedURL *url.URL) string {
+	path := parsedURL.Path
+
+	if path == "" {
+		path = "/"
+	}
+
+	return strings.ToLower(parsedURL.Scheme) + "://" + strings.ToLower(parsedURL.Host) + path
+}
+
+func WriteEndpointMap(w io.Writer, endpoints map[string]EndpointInfo) error {
+	JSON, err := json.MarshalIndent(endpoints, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(JSON, '\n'))
+
+	return err
+}
+
+func containsInt(slice []int, i int) bool {
+	for _, item := range slice {
+		if item == i {
+			return true
+		}
+	}
+
+	return false
+}
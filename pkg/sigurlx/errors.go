@@ -0,0 +1,40 @@
+package sigurlx
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"syscall"
+)
+
+func ErrorKind(err error) string {
+	var DNSError *net.DNSError
+	var URLError *url.Error
+	var netError net.Error
+	var certificateError x509.CertificateInvalidError
+
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &DNSError):
+		return "dns"
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return "connection_refused"
+	case errors.Is(err, syscall.ECONNRESET):
+		return "connection_reset"
+	case errors.As(err, &certificateError), strings.Contains(err.Error(), "tls:"):
+		return "tls"
+	case errors.As(err, &netError) && netError.Timeout():
+		return "timeout"
+	case errors.As(err, &URLError) && URLError.Op == "parse":
+		return "parse"
+	}
+
+	return "other"
+}
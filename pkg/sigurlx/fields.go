@@ -0,0 +1,153 @@
+package sigurlx
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// ResultFields returns the json names of the Result fields, in their
+// default order.
+func ResultFields() []string {
+	var fields []string
+
+	resultType := reflect.TypeOf(Result{})
+
+	for i := 0; i < resultType.NumField(); i++ {
+		fields = append(fields, strings.Split(resultType.Field(i).Tag.Get("json"), ",")[0])
+	}
+
+	return fields
+}
+
+func (sigurlx *Sigurlx) initOutputFields() error {
+	known := make(map[string]bool)
+
+	for _, field := range ResultFields() {
+		known[field] = true
+	}
+
+	for _, field := range sigurlx.Options.OutputFields {
+		if !known[field] {
+			return fmt.Errorf("unknown output field: %s", field)
+		}
+	}
+
+	return nil
+}
+
+// MarshalFields marshals a Result or Results keeping only fields, in the
+// order they are given. Fields left empty are still dropped unless full.
+func MarshalFields(v interface{}, fields []string, full bool) ([]byte, error) {
+	marshal := json.Marshal
+
+	if full {
+		marshal = MarshalFull
+	}
+
+	JSON, err := marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	// nil Results marshal to null, which has no fields to pick
+	if len(fields) == 0 || bytes.Equal(JSON, []byte("null")) {
+		return JSON, nil
+	}
+
+	var buf bytes.Buffer
+
+	if bytes.HasPrefix(JSON, []byte("[")) {
+		var objects []map[string]json.RawMessage
+
+		if err := json.Unmarshal(JSON, &objects); err != nil {
+			return nil, err
+		}
+
+		buf.WriteByte('[')
+
+		for i, object := range objects {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			writeFields(&buf, object, fields)
+		}
+
+		buf.WriteByte(']')
+
+		return buf.Bytes(), nil
+	}
+
+	var object map[string]json.RawMessage
+
+	if err := json.Unmarshal(JSON, &object); err != nil {
+		return nil, err
+	}
+
+	writeFields(&buf, object, fields)
+
+	return buf.Bytes(), nil
+}
+
+func MarshalFieldsIndent(v interface{}, fields []string, full bool, prefix, indent string) ([]byte, error) {
+	JSON, err := MarshalFields(v, fields, full)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, JSON, prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeFields(buf *bytes.Buffer, object map[string]json.RawMessage, fields []string) {
+	buf.WriteByte('{')
+
+	written := 0
+
+	for _, field := range fields {
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+
+		if written > 0 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(field)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+
+		written++
+	}
+
+	buf.WriteByte('}')
+}
+
+func WriteResultFields(w io.Writer, result Result, fields []string, full, pretty bool) error {
+	return writeResult(w, result, fieldsMarshaler(fields, full, pretty))
+}
+
+func (results Results) SaveToFieldsJSON(PATH string, fields []string, full bool) error {
+	return results.saveToJSON(PATH, fieldsMarshaler(fields, full, true))
+}
+
+func fieldsMarshaler(fields []string, full, pretty bool) func(interface{}) ([]byte, error) {
+	return func(v interface{}) ([]byte, error) {
+		if pretty {
+			return MarshalFieldsIndent(v, fields, full, "", "\t")
+		}
+
+		return MarshalFields(v, fields, full)
+	}
+}
@@ -0,0 +1,27 @@
+package sigurlx
+
+import "regexp"
+
+func FilterURLs(URLs []string, include, exclude *regexp.Regexp) []string {
+	var filtered []string
+
+	for _, URL := range URLs {
+		if MatchURL(URL, include, exclude) {
+			filtered = append(filtered, URL)
+		}
+	}
+
+	return filtered
+}
+
+func MatchURL(URL string, include, exclude *regexp.Regexp) bool {
+	if include != nil && !include.MatchString(URL) {
+		return false
+	}
+
+	if exclude != nil && exclude.MatchString(URL) {
+		return false
+	}
+
+	return true
+}
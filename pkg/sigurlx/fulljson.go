@@ -0,0 +1,339 @@
+package sigurlx
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// MarshalFull is like json.Marshal but ignores omitempty, so that empty
+// fields are kept as explicit empty arrays, objects, strings and zeros.
+func MarshalFull(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := marshalFull(&buf, reflect.ValueOf(v)); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func MarshalFullIndent(v interface{}, prefix, indent string) ([]byte, error) {
+	JSON, err := MarshalFull(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := json.Indent(&buf, JSON, prefix, indent); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+var (
+	marshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+func marshalFull(buf *bytes.Buffer, value reflect.Value) error {
+	if (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil() {
+		buf.WriteString("null")
+
+		return nil
+	}
+
+	// types that marshal themselves are left to encoding/json
+	if value.Type().Implements(marshalerType) || value.Type().Implements(textMarshalerType) ||
+		(value.CanAddr() && (value.Addr().Type().Implements(marshalerType) || value.Addr().Type().Implements(textMarshalerType))) {
+		return marshalScalar(buf, value)
+	}
+
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return marshalFull(buf, value.Elem())
+	case reflect.Struct:
+		buf.WriteByte('{')
+
+		written := 0
+
+		for _, field := range fullFields(value.Type()) {
+			fieldValue, ok := fieldByIndex(value, field.index)
+			if !ok {
+				continue
+			}
+
+			if written > 0 {
+				buf.WriteByte(',')
+			}
+
+			key, _ := json.Marshal(field.name)
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			if field.quoted {
+				if err := marshalQuoted(buf, fieldValue); err != nil {
+					return err
+				}
+			} else if err := marshalFull(buf, fieldValue); err != nil {
+				return err
+			}
+
+			written++
+		}
+
+		buf.WriteByte('}')
+	case reflect.Slice:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return marshalScalar(buf, value)
+		}
+
+		fallthrough
+	case reflect.Array:
+		buf.WriteByte('[')
+
+		for i := 0; i < value.Len(); i++ {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			if err := marshalFull(buf, value.Index(i)); err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case reflect.Map:
+		if value.Len() == 0 {
+			buf.WriteString("{}")
+
+			return nil
+		}
+
+		return marshalScalar(buf, value)
+	default:
+		return marshalScalar(buf, value)
+	}
+
+	return nil
+}
+
+func marshalScalar(buf *bytes.Buffer, value reflect.Value) error {
+	JSON, err := json.Marshal(value.Interface())
+	if err != nil {
+		return err
+	}
+
+	buf.Write(JSON)
+
+	return nil
+}
+
+// marshalQuoted writes a field with the ",string" option, which encoding/json
+// wraps in a JSON string.
+func marshalQuoted(buf *bytes.Buffer, value reflect.Value) error {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			buf.WriteString("null")
+
+			return nil
+		}
+
+		value = value.Elem()
+	}
+
+	JSON, err := json.Marshal(value.Interface())
+	if err != nil {
+		return err
+	}
+
+	quoted, err := json.Marshal(string(JSON))
+	if err != nil {
+		return err
+	}
+
+	buf.Write(quoted)
+
+	return nil
+}
+
+type fullField struct {
+	name   string
+	index  []int
+	tagged bool
+	quoted bool
+}
+
+// fullFields lists the fields encoding/json would encode for t, with the
+// fields of embedded structs promoted by the same rules.
+func fullFields(t reflect.Type) []fullField {
+	var fields []fullField
+
+	type embedded struct {
+		t     reflect.Type
+		index []int
+	}
+
+	next := []embedded{{t: t}}
+	visited := make(map[reflect.Type]bool)
+	taken := make(map[string]bool)
+
+	for len(next) > 0 {
+		current := next
+		next = nil
+
+		// fields of one depth, the shallowest name wins
+		var level []fullField
+
+		for _, e := range current {
+			if visited[e.t] {
+				continue
+			}
+
+			visited[e.t] = true
+
+			for i := 0; i < e.t.NumField(); i++ {
+				field := e.t.Field(i)
+
+				fieldType := field.Type
+				if fieldType.Kind() == reflect.Ptr {
+					fieldType = fieldType.Elem()
+				}
+
+				if field.Anonymous {
+					if field.PkgPath != "" && fieldType.Kind() != reflect.Struct {
+						continue
+					}
+				} else if field.PkgPath != "" {
+					continue
+				}
+
+				tag := field.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+
+				tokens := strings.Split(tag, ",")
+
+				index := append(append([]int{}, e.index...), i)
+
+				if tokens[0] == "" && field.Anonymous && fieldType.Kind() == reflect.Struct {
+					next = append(next, embedded{t: fieldType, index: index})
+
+					continue
+				}
+
+				name := tokens[0]
+				if name == "" {
+					name = field.Name
+				}
+
+				quoted := false
+
+				for _, option := range tokens[1:] {
+					if option == "string" {
+						switch fieldType.Kind() {
+						case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+							reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+							reflect.Float32, reflect.Float64, reflect.String:
+							quoted = true
+						}
+					}
+				}
+
+				level = append(level, fullField{name: name, index: index, tagged: tokens[0] != "", quoted: quoted})
+			}
+		}
+
+		fields = append(fields, dominantFields(taken, level)...)
+	}
+
+	sort.Slice(fields, func(i, j int) bool {
+		for k, x := range fields[i].index {
+			if k >= len(fields[j].index) {
+				return false
+			}
+
+			if x != fields[j].index[k] {
+				return x < fields[j].index[k]
+			}
+		}
+
+		return len(fields[i].index) < len(fields[j].index)
+	})
+
+	return fields
+}
+
+// dominantFields keeps the fields of level whose name isn't taken by a
+// shallower field, and adds their names to taken. Names used twice at the same
+// depth are kept only if exactly one of them is tagged, otherwise encoding/json
+// drops them, deeper fields of that name included.
+func dominantFields(taken map[string]bool, level []fullField) []fullField {
+	byName := make(map[string][]fullField)
+
+	var names []string
+
+	for _, field := range level {
+		if taken[field.name] {
+			continue
+		}
+
+		if _, ok := byName[field.name]; !ok {
+			names = append(names, field.name)
+		}
+
+		byName[field.name] = append(byName[field.name], field)
+	}
+
+	var dominant []fullField
+
+	for _, name := range names {
+		taken[name] = true
+
+		candidates := byName[name]
+
+		if len(candidates) == 1 {
+			dominant = append(dominant, candidates[0])
+
+			continue
+		}
+
+		var tagged []fullField
+
+		for _, field := range candidates {
+			if field.tagged {
+				tagged = append(tagged, field)
+			}
+		}
+
+		if len(tagged) == 1 {
+			dominant = append(dominant, tagged[0])
+		}
+	}
+
+	return dominant
+}
+
+// fieldByIndex is value.FieldByIndex, except that a nil embedded pointer
+// reports the field as absent, as encoding/json skips it.
+func fieldByIndex(value reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				return reflect.Value{}, false
+			}
+
+			value = value.Elem()
+		}
+
+		value = value.Field(x)
+	}
+
+	return value, true
+}
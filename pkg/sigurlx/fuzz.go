@@ -0,0 +1,34 @@
+package sigurlx
+
+import "net/url"
+
+const FuzzMarker = "FUZZ"
+
+// FuzzURLs returns the result's URL once per reflected param, with that
+// param's value replaced by FuzzMarker, ready for other scanners.
+func FuzzURLs(result Result) []string {
+	var URLs []string
+
+	parsedURL, err := url.Parse(result.URL)
+	if err != nil {
+		return URLs
+	}
+
+	for _, param := range result.ReflectedParams {
+		if param.Location == "matrix" {
+			URLs = append(URLs, setMatrixParam(parsedURL, param.Param, FuzzMarker).String())
+
+			continue
+		}
+
+		query := parsedURL.Query()
+		query.Set(param.Param, FuzzMarker)
+
+		fuzzURL := *parsedURL
+		fuzzURL.RawQuery = query.Encode()
+
+		URLs = append(URLs, fuzzURL.String())
+	}
+
+	return URLs
+}
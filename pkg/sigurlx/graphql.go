@@ -0,0 +1,62 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type GraphQL struct {
+	Introspection bool `json:"introspection"`
+}
+
+func isGraphQLQuery(query url.Values) bool {
+	if query.Get("operationName") != "" {
+		return true
+	}
+
+	value := strings.TrimSpace(query.Get("query"))
+
+	return strings.HasPrefix(value, "{") ||
+		strings.HasPrefix(value, "query") ||
+		strings.HasPrefix(value, "mutation")
+}
+
+func (sigurlx *Sigurlx) GraphQLProbe(parsedURL *url.URL) (*GraphQL, error) {
+	endpoint := *parsedURL
+	endpoint.RawQuery = ""
+	endpoint.Fragment = ""
+
+	body, err := json.Marshal(map[string]string{"query": "query{__schema{queryType{name}}}"})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := sigurlx.DoHTTPRequest(http.MethodPost, endpoint.String(), body, map[string]string{
+		"Content-Type": "application/json",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var introspection struct {
+		Data struct {
+			Schema struct {
+				QueryType struct {
+					Name string `json:"name"`
+				} `json:"queryType"`
+			} `json:"__schema"`
+		} `json:"data"`
+	}
+
+	graphQL := &GraphQL{}
+
+	if res.StatusCode == http.StatusOK {
+		if err := json.Unmarshal(res.Body, &introspection); err == nil {
+			graphQL.Introspection = introspection.Data.Schema.QueryType.Name != ""
+		}
+	}
+
+	return graphQL, nil
+}
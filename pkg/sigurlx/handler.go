@@ -0,0 +1,47 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+const handlerPayload = `" onmouseover=sigx `
+
+// checkHandlerInjection reports whether the quote and event handler of
+// handlerPayload come back unencoded inside an HTML tag, i.e, the param can
+// break out of an attribute value and add its own.
+func (sigurlx *Sigurlx) checkHandlerInjection(parsedURL *url.URL, query url.Values, param string) (bool, Response, error) {
+	reflected, res, err := sigurlx.checkAppend(parsedURL, query, param, handlerPayload)
+	if err != nil || !reflected {
+		return false, res, err
+	}
+
+	body := string(res.Body)
+
+	for offset := 0; ; {
+		i := strings.Index(body[offset:], handlerPayload)
+		if i < 0 {
+			break
+		}
+
+		i += offset
+
+		if strings.LastIndex(body[:i], "<") > strings.LastIndex(body[:i], ">") {
+			return true, res, nil
+		}
+
+		offset = i + len(handlerPayload)
+	}
+
+	return false, res, nil
+}
+
+func containsString(slice []string, s string) bool {
+	for _, item := range slice {
+		if item == s {
+			return true
+		}
+	}
+
+	return false
+}
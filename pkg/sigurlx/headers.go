@@ -0,0 +1,21 @@
+package sigurlx
+
+import (
+	"fmt"
+	"os"
+)
+
+func (sigurlx *Sigurlx) initHeaders() error {
+	sigurlx.headers = make(map[string]string)
+
+	for header, env := range sigurlx.Options.HeaderEnv {
+		value, ok := os.LookupEnv(env)
+		if !ok {
+			return fmt.Errorf("environment variable %s for header %s is not set", env, header)
+		}
+
+		sigurlx.headers[header] = value
+	}
+
+	return nil
+}
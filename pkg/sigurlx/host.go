@@ -0,0 +1,36 @@
+package sigurlx
+
+import (
+	"net/http"
+	"strings"
+)
+
+const hostCanary = "sigurlx-host-probe.com"
+
+var hostInjectionHeaders = []string{"Host", "X-Forwarded-Host", "X-Host", "X-Forwarded-Server"}
+
+type HostInjection struct {
+	Header   string `json:"header,omitempty"`
+	Location string `json:"location,omitempty"`
+}
+
+func (sigurlx *Sigurlx) HostInjectionProbe(URL string) ([]HostInjection, error) {
+	var hostInjections []HostInjection
+
+	for _, header := range hostInjectionHeaders {
+		res, err := sigurlx.DoHTTPRequest(http.MethodGet, URL, nil, map[string]string{header: hostCanary})
+		if err != nil {
+			continue
+		}
+
+		// a poisoned redirect is the more useful of the two to report
+		switch {
+		case strings.Contains(res.RedirectLocation, hostCanary):
+			hostInjections = append(hostInjections, HostInjection{Header: header, Location: "redirect"})
+		case strings.Contains(string(res.Body), hostCanary):
+			hostInjections = append(hostInjections, HostInjection{Header: header, Location: "body"})
+		}
+	}
+
+	return hostInjections, nil
+}
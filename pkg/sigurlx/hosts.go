@@ -0,0 +1,89 @@
+package sigurlx
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type Host struct {
+	Name       string `json:"name,omitempty"`
+	Responsive bool   `json:"responsive,omitempty"`
+}
+
+// Hosts returns the unique hosts of results, a host being responsive if any
+// of its urls got an HTTP response.
+func Hosts(results Results) []Host {
+	var hosts []Host
+
+	index := make(map[string]int)
+
+	for _, result := range results {
+		if result.Host == "" {
+			continue
+		}
+
+		i, ok := index[result.Host]
+		if !ok {
+			i = len(hosts)
+			index[result.Host] = i
+			hosts = append(hosts, Host{Name: result.Host})
+		}
+
+		if result.StatusCode > 0 {
+			hosts[i].Responsive = true
+		}
+	}
+
+	sort.Slice(hosts, func(i, j int) bool {
+		return hosts[i].Name < hosts[j].Name
+	})
+
+	return hosts
+}
+
+func ResponsiveHosts(results Results) []string {
+	var responsive []string
+
+	for _, host := range Hosts(results) {
+		if host.Responsive {
+			responsive = append(responsive, host.Name)
+		}
+	}
+
+	return responsive
+}
+
+// GroupByHost groups results by their host, leaving out those without one.
+func GroupByHost(results Results) map[string]Results {
+	groups := make(map[string]Results)
+
+	for _, result := range results {
+		if result.Host != "" {
+			groups[result.Host] = append(groups[result.Host], result)
+		}
+	}
+
+	return groups
+}
+
+// SaveByHost writes the results of each host to directory/<host>.json
+func (results Results) SaveByHost(directory string, fields []string, full bool) error {
+	if err := os.MkdirAll(directory, os.ModePerm); err != nil {
+		return err
+	}
+
+	marshal := fieldsMarshaler(fields, full, true)
+
+	for host, group := range GroupByHost(results) {
+		// ports would make for awkward file names on some systems
+		name := strings.Replace(host, ":", "_", -1) + ".json"
+
+		if err := group.saveToJSON(filepath.Join(directory, name), marshal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
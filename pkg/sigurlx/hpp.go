@@ -0,0 +1,54 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+type HPP struct {
+	Param    string `json:"param,omitempty"`
+	Behavior string `json:"behavior,omitempty"`
+}
+
+func (sigurlx *Sigurlx) HPPProbe(parsedURL *url.URL, query url.Values) ([]HPP, error) {
+	var HPPs []HPP
+
+	first, last := "sigurlxhppa", "sigurlxhppb"
+
+	tested := sigurlx.testedParams(query)
+
+	for param := range query {
+		if !sigurlx.shouldReflect(param) || !tested[param] {
+			continue
+		}
+
+		pollutedURL := *parsedURL
+		pollutedURL.RawQuery = setRawParam(parsedURL.RawQuery, param, first, last)
+
+		res, err := sigurlx.DoHTTP(pollutedURL.String())
+		if err != nil {
+			continue
+		}
+
+		body := string(res.Body)
+
+		var behavior string
+
+		switch {
+		case strings.Contains(body, first+","+last) || strings.Contains(body, first+last):
+			behavior = "concatenated"
+		case strings.Contains(body, first) && strings.Contains(body, last):
+			behavior = "both"
+		case strings.Contains(body, first):
+			behavior = "first"
+		case strings.Contains(body, last):
+			behavior = "last"
+		default:
+			continue
+		}
+
+		HPPs = append(HPPs, HPP{Param: param, Behavior: behavior})
+	}
+
+	return HPPs, nil
+}
@@ -0,0 +1,37 @@
+package sigurlx
+
+import (
+	"regexp"
+	"strings"
+)
+
+var tagRegex = regexp.MustCompile(`(?is)<([a-z][a-z0-9-]*)\b[^>]*>`)
+
+// InlineHandlersProbe extracts the inline event handlers, e.g onclick, and
+// javascript: URLs of an html body, as "tag attribute=value". The DOM regex
+// only sees those once they run, they are part of the markup.
+func (sigurlx *Sigurlx) InlineHandlersProbe(res Response) ([]string, error) {
+	var inlineHandlers []string
+
+	seen := make(map[string]bool)
+
+	for _, tag := range tagRegex.FindAllStringSubmatch(string(res.Body), -1) {
+		for _, match := range attributeRegex.FindAllStringSubmatch(tag[0], -1) {
+			attribute := strings.ToLower(match[1])
+			value := strings.TrimSpace(match[2] + match[3] + match[4])
+
+			if !strings.HasPrefix(attribute, "on") && !strings.HasPrefix(strings.ToLower(value), "javascript:") {
+				continue
+			}
+
+			handler := strings.ToLower(tag[1]) + " " + attribute + "=" + value
+
+			if !seen[handler] {
+				seen[handler] = true
+				inlineHandlers = append(inlineHandlers, handler)
+			}
+		}
+	}
+
+	return inlineHandlers, nil
+}
@@ -0,0 +1,29 @@
+package sigurlx
+
+import "sync"
+
+type InvalidURL struct {
+	URL   string `json:"url,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type invalidURLs struct {
+	mutex *sync.Mutex
+	list  []InvalidURL
+}
+
+func (invalid *invalidURLs) add(URL string, err error) {
+	invalid.mutex.Lock()
+	defer invalid.mutex.Unlock()
+
+	invalid.list = append(invalid.list, InvalidURL{URL: URL, Error: err.Error()})
+}
+
+// InvalidURLs returns the urls skipped for not parsing when Options.Lenient
+// is set.
+func (sigurlx *Sigurlx) InvalidURLs() []InvalidURL {
+	sigurlx.invalid.mutex.Lock()
+	defer sigurlx.invalid.mutex.Unlock()
+
+	return append([]InvalidURL(nil), sigurlx.invalid.list...)
+}
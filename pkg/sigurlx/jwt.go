@@ -0,0 +1,111 @@
+package sigurlx
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var jwtRegex = regexp.MustCompile(`^eyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]*\.[A-Za-z0-9_-]*$`)
+
+// shared secret algorithms, which a leaked token lets be cracked offline
+var weakJWTAlgs = map[string]bool{"HS256": true, "HS384": true, "HS512": true}
+
+var sensitiveJWTClaims = []string{"password", "passwd", "pwd", "secret", "ssn", "card", "api_key", "apikey", "private_key"}
+
+type JWTFinding struct {
+	Param  string   `json:"param,omitempty"`
+	Alg    string   `json:"alg,omitempty"`
+	Issues []string `json:"issues,omitempty"`
+}
+
+// JWTProbe decodes the JWTs passed as param values, without verifying them,
+// and flags none or weak algorithms, expired tokens and sensitive claims.
+func (sigurlx *Sigurlx) JWTProbe(query map[string][]string) ([]JWTFinding, error) {
+	var findings []JWTFinding
+
+	params := make([]string, 0, len(query))
+
+	for param := range query {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		for _, value := range query[param] {
+			if finding, ok := checkJWT(value); ok {
+				finding.Param = param
+				findings = append(findings, finding)
+			}
+		}
+	}
+
+	return findings, nil
+}
+
+func checkJWT(token string) (finding JWTFinding, ok bool) {
+	if !jwtRegex.MatchString(token) {
+		return finding, false
+	}
+
+	parts := strings.Split(token, ".")
+
+	var header struct {
+		Alg string `json:"alg"`
+	}
+
+	if err := decodeJWTPart(parts[0], &header); err != nil {
+		return finding, false
+	}
+
+	var claims map[string]interface{}
+
+	if err := decodeJWTPart(parts[1], &claims); err != nil {
+		return finding, false
+	}
+
+	finding.Alg = header.Alg
+
+	switch {
+	case strings.EqualFold(header.Alg, "none") || header.Alg == "":
+		finding.Issues = append(finding.Issues, "alg-none")
+	case weakJWTAlgs[strings.ToUpper(header.Alg)]:
+		finding.Issues = append(finding.Issues, "weak-alg")
+	}
+
+	if exp, ok := claims["exp"].(float64); ok && time.Unix(int64(exp), 0).Before(time.Now()) {
+		finding.Issues = append(finding.Issues, "expired")
+	}
+
+	var sensitive []string
+
+	for claim := range claims {
+		for _, name := range sensitiveJWTClaims {
+			if strings.Contains(strings.ToLower(claim), name) {
+				sensitive = append(sensitive, "sensitive-claim:"+claim)
+
+				break
+			}
+		}
+	}
+
+	sort.Strings(sensitive)
+
+	finding.Issues = append(finding.Issues, sensitive...)
+
+	return finding, true
+}
+
+func decodeJWTPart(part string, v interface{}) error {
+	// JWTs use unpadded base64url, but some encoders pad anyway
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(part, "="))
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, v)
+}
@@ -0,0 +1,48 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+)
+
+// testedParams returns the params of query that probes may test, at most
+// Options.MaxParamsPerURL of them with the commonly vulnerable ones first.
+func (sigurlx *Sigurlx) testedParams(query url.Values) map[string]bool {
+	names := make([]string, 0, len(query))
+
+	for name := range query {
+		names = append(names, name)
+	}
+
+	if sigurlx.Options.MaxParamsPerURL > 0 && len(names) > sigurlx.Options.MaxParamsPerURL {
+		risky := make(map[string]bool)
+
+		for _, name := range names {
+			for i := range sigurlx.Params {
+				if sigurlx.matchParam(sigurlx.Params[i], name) {
+					risky[name] = true
+
+					break
+				}
+			}
+		}
+
+		sort.Slice(names, func(i, j int) bool {
+			if risky[names[i]] != risky[names[j]] {
+				return risky[names[i]]
+			}
+
+			return names[i] < names[j]
+		})
+
+		names = names[:sigurlx.Options.MaxParamsPerURL]
+	}
+
+	tested := make(map[string]bool, len(names))
+
+	for _, name := range names {
+		tested[name] = true
+	}
+
+	return tested
+}
@@ -0,0 +1,92 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var linkRegex = regexp.MustCompile(`(?i)\b(?:href|src|action)\s*=\s*["']([^"']+)["']`)
+
+// LinksProbe returns the absolute http(s) links of an html page.
+func (sigurlx *Sigurlx) LinksProbe(parsedURL *url.URL, res Response) ([]string, error) {
+	var links []string
+
+	seen := make(map[string]bool)
+
+	for _, match := range linkRegex.FindAllSubmatch(res.Body, -1) {
+		link, ok := resolveLink(parsedURL, string(match[1]))
+		if !ok || seen[link] {
+			continue
+		}
+
+		seen[link] = true
+		links = append(links, link)
+	}
+
+	sort.Strings(links)
+
+	return links, nil
+}
+
+// CollectLinks dedups the URLs discovered across results, i.e links, listed
+// entries, redirects and swagger paths, leaving out the URLs already scanned,
+// so that they can be fed back into ProcessAll.
+func CollectLinks(results Results) []string {
+	var links []string
+
+	seen := make(map[string]bool)
+
+	for _, result := range results {
+		seen[result.URL] = true
+	}
+
+	add := func(link string) {
+		if !seen[link] {
+			seen[link] = true
+			links = append(links, link)
+		}
+	}
+
+	for _, result := range results {
+		parsedURL, err := url.Parse(result.URL)
+		if err != nil {
+			continue
+		}
+
+		discovered := append(append([]string{}, result.Links...), result.ListedEntries...)
+
+		if result.RedirectLocation != "" {
+			discovered = append(discovered, result.RedirectLocation)
+		}
+
+		for _, endpoint := range result.SwaggerEndpoints {
+			// templated paths, e.g /users/{id}, can't be requested as is
+			if !strings.Contains(endpoint.Path, "{") {
+				discovered = append(discovered, endpoint.Path)
+			}
+		}
+
+		for _, link := range discovered {
+			if link, ok := resolveLink(parsedURL, link); ok {
+				add(link)
+			}
+		}
+	}
+
+	sort.Strings(links)
+
+	return links
+}
+
+func resolveLink(parsedURL *url.URL, link string) (string, bool) {
+	resolved, err := parsedURL.Parse(strings.TrimSpace(link))
+	if err != nil || (resolved.Scheme != "http" && resolved.Scheme != "https") {
+		return "", false
+	}
+
+	resolved.Fragment = ""
+
+	return resolved.String(), true
+}
@@ -0,0 +1,123 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// getMatrixQuery parses the matrix params of the path segments, e.g
+// /path;jsessionid=abc;key=val
+func getMatrixQuery(escapedPath string) url.Values {
+	query := url.Values{}
+
+	for _, segment := range strings.Split(escapedPath, "/") {
+		parts := strings.Split(segment, ";")
+
+		for _, part := range parts[1:] {
+			kv := strings.SplitN(part, "=", 2)
+
+			name, err := url.PathUnescape(kv[0])
+			if err != nil || name == "" {
+				continue
+			}
+
+			var value string
+
+			if len(kv) == 2 {
+				if value, err = url.PathUnescape(kv[1]); err != nil {
+					continue
+				}
+			}
+
+			query.Add(name, value)
+		}
+	}
+
+	return query
+}
+
+// setMatrixParam returns a copy of parsedURL with every matrix param named
+// param set to value.
+func setMatrixParam(parsedURL *url.URL, param, value string) *url.URL {
+	segments := strings.Split(parsedURL.EscapedPath(), "/")
+
+	for i, segment := range segments {
+		parts := strings.Split(segment, ";")
+
+		for j, part := range parts[1:] {
+			name, err := url.PathUnescape(strings.SplitN(part, "=", 2)[0])
+			if err == nil && name == param {
+				parts[j+1] = url.PathEscape(name) + "=" + url.PathEscape(value)
+			}
+		}
+
+		segments[i] = strings.Join(parts, ";")
+	}
+
+	injectedURL := *parsedURL
+	injectedURL.RawPath = strings.Join(segments, "/")
+	injectedURL.Path, _ = url.PathUnescape(injectedURL.RawPath)
+
+	return &injectedURL
+}
+
+// MatrixReflectedParamsProbe is ReflectedParamsProbe for matrix params.
+func (sigurlx *Sigurlx) MatrixReflectedParamsProbe(parsedURL *url.URL, matrixQuery url.Values, res Response) ([]ReflectedParam, error) {
+	var reflectedParams []ReflectedParam
+
+	if !sigurlx.isReflectable(res) {
+		return reflectedParams, nil
+	}
+
+	tested := sigurlx.testedParams(matrixQuery)
+
+	params := make([]string, 0, len(matrixQuery))
+
+	for param := range matrixQuery {
+		value := matrixQuery.Get(param)
+
+		if tested[param] && sigurlx.shouldReflect(param) && value != "" && strings.Contains(string(res.Body), value) {
+			params = append(params, param)
+		}
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		param := param
+
+		reflectedCharacters, raw, cacheable, sniffable := sigurlx.testCharacters(func(token string) (bool, Response, error) {
+			return sigurlx.checkMatrixAppend(parsedURL, matrixQuery, param, token)
+		})
+
+		if len(reflectedCharacters) > 2 {
+			reflectedParams = append(reflectedParams, ReflectedParam{
+				Param:      param,
+				Characters: reflectedCharacters,
+				Location:   "matrix",
+				Cacheable:  cacheable,
+				Sniffable:  sniffable,
+				Raw:        raw,
+			})
+		}
+	}
+
+	return reflectedParams, nil
+}
+
+// checkMatrixAppend is checkAppend for matrix params.
+func (sigurlx *Sigurlx) checkMatrixAppend(parsedURL *url.URL, matrixQuery url.Values, param, token string) (bool, Response, error) {
+	value := matrixQuery.Get(param) + sigurlx.Options.PayloadPrefix + token + sigurlx.Options.PayloadSuffix
+
+	res, err := sigurlx.DoHTTP(setMatrixParam(parsedURL, param, value).String())
+	if err != nil {
+		return false, res, err
+	}
+
+	if !sigurlx.isReflectable(res) {
+		return false, res, nil
+	}
+
+	return strings.Contains(string(res.Body), token), res, nil
+}
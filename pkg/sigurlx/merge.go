@@ -0,0 +1,69 @@
+package sigurlx
+
+import "reflect"
+
+func MergeResults(sets ...Results) Results {
+	var merged Results
+
+	index := make(map[string]int)
+
+	for _, set := range sets {
+		for _, result := range set {
+			i, ok := index[result.URL]
+			if !ok {
+				index[result.URL] = len(merged)
+				merged = append(merged, result)
+
+				continue
+			}
+
+			mergeResult(&merged[i], result)
+		}
+	}
+
+	return merged
+}
+
+// mergeResult fills the zero fields of dst from src and appends the
+// elements of src's slice fields that dst doesn't already hold.
+func mergeResult(dst *Result, src Result) {
+	dstValue := reflect.ValueOf(dst).Elem()
+	srcValue := reflect.ValueOf(src)
+
+	for i := 0; i < dstValue.NumField(); i++ {
+		dstField := dstValue.Field(i)
+		srcField := srcValue.Field(i)
+
+		if dstField.Kind() == reflect.Slice {
+			// append to a copy, dst's backing array may still be the caller's
+			mergedField := reflect.MakeSlice(dstField.Type(), dstField.Len(), dstField.Len()+srcField.Len())
+			reflect.Copy(mergedField, dstField)
+
+			for j := 0; j < srcField.Len(); j++ {
+				if !containsValue(mergedField, srcField.Index(j)) {
+					mergedField = reflect.Append(mergedField, srcField.Index(j))
+				}
+			}
+
+			if mergedField.Len() > dstField.Len() {
+				dstField.Set(mergedField)
+			}
+
+			continue
+		}
+
+		if dstField.IsZero() {
+			dstField.Set(srcField)
+		}
+	}
+}
+
+func containsValue(slice, value reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), value.Interface()) {
+			return true
+		}
+	}
+
+	return false
+}
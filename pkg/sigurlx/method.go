@@ -0,0 +1,48 @@
+package sigurlx
+
+import (
+	"net/http"
+	"strings"
+)
+
+var methodOverrideHeaders = []string{"X-HTTP-Method-Override", "X-HTTP-Method", "X-Method-Override"}
+
+func (sigurlx *Sigurlx) AllowedMethodsProbe(URL string) ([]string, error) {
+	var allowedMethods []string
+
+	res, err := sigurlx.DoHTTPRequest(http.MethodOptions, URL, nil, nil)
+	if err != nil {
+		return allowedMethods, err
+	}
+
+	for _, value := range res.Headers["Allow"] {
+		for _, method := range strings.Split(value, ",") {
+			if method = strings.ToUpper(strings.TrimSpace(method)); method != "" {
+				allowedMethods = append(allowedMethods, method)
+			}
+		}
+	}
+
+	return allowedMethods, nil
+}
+
+func (sigurlx *Sigurlx) MethodOverrideProbe(URL string, res Response) ([]string, error) {
+	var overrideHeaders []string
+
+	for _, header := range methodOverrideHeaders {
+		// a bogus method only changes the response if the header is honored
+		overridden, err := sigurlx.DoHTTPRequest(http.MethodGet, URL, nil, map[string]string{header: "SIGURLX"})
+		if err != nil {
+			continue
+		}
+
+		if overridden.StatusCode != res.StatusCode &&
+			(overridden.StatusCode == http.StatusMethodNotAllowed ||
+				overridden.StatusCode == http.StatusNotImplemented ||
+				overridden.StatusCode == http.StatusBadRequest) {
+			overrideHeaders = append(overrideHeaders, header)
+		}
+	}
+
+	return overrideHeaders, nil
+}
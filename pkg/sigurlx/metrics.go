@@ -0,0 +1,87 @@
+package sigurlx
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+type Metrics struct {
+	Requests         int64            `json:"requests"`
+	StatusClasses    map[string]int64 `json:"status_classes,omitempty"`
+	Errors           map[string]int64 `json:"errors,omitempty"`
+	Reflections      int64            `json:"reflections"`
+	AverageLatency   time.Duration    `json:"average_latency"`
+	BytesTransferred int64            `json:"bytes_transferred"`
+}
+
+var errorKinds = []string{"timeout", "dns", "connection_refused", "connection_reset", "tls", "parse", "other"}
+
+// metrics holds the live counters. The int64 fields come first so that they
+// stay 64-bit aligned for the atomic operations on 32-bit platforms.
+type metrics struct {
+	requests    int64
+	latency     int64
+	bytes       int64
+	reflections int64
+	statuses    [6]int64
+	errors      map[string]*int64
+}
+
+func newMetrics() *metrics {
+	m := &metrics{errors: make(map[string]*int64)}
+
+	// the map is only read after this, so lookups need no locking
+	for _, kind := range errorKinds {
+		m.errors[kind] = new(int64)
+	}
+
+	return m
+}
+
+func (m *metrics) observeRequest(latency time.Duration, statusCode int, err error) {
+	atomic.AddInt64(&m.requests, 1)
+	atomic.AddInt64(&m.latency, int64(latency))
+
+	if err != nil {
+		if counter, ok := m.errors[ErrorKind(err)]; ok {
+			atomic.AddInt64(counter, 1)
+		}
+
+		return
+	}
+
+	if class := statusCode / 100; class > 0 && class < len(m.statuses) {
+		atomic.AddInt64(&m.statuses[class], 1)
+	}
+}
+
+func (sigurlx *Sigurlx) Metrics() Metrics {
+	m := sigurlx.metrics
+
+	snapshot := Metrics{
+		Requests:         atomic.LoadInt64(&m.requests),
+		StatusClasses:    make(map[string]int64),
+		Errors:           make(map[string]int64),
+		Reflections:      atomic.LoadInt64(&m.reflections),
+		BytesTransferred: atomic.LoadInt64(&m.bytes),
+	}
+
+	if snapshot.Requests > 0 {
+		snapshot.AverageLatency = time.Duration(atomic.LoadInt64(&m.latency) / snapshot.Requests)
+	}
+
+	for class := 1; class < len(m.statuses); class++ {
+		if count := atomic.LoadInt64(&m.statuses[class]); count > 0 {
+			snapshot.StatusClasses[fmt.Sprintf("%dxx", class)] = count
+		}
+	}
+
+	for kind, counter := range m.errors {
+		if count := atomic.LoadInt64(counter); count > 0 {
+			snapshot.Errors[kind] = count
+		}
+	}
+
+	return snapshot
+}
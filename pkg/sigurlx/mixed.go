@@ -0,0 +1,57 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var mixedContentTagRegex = regexp.MustCompile(`(?is)<(script|link|img|iframe|frame|audio|video|source|track|embed|object)\b[^>]*>`)
+
+// link relations a browser loads the target of
+var loadedLinkRels = []string{"stylesheet", "icon", "preload", "modulepreload", "manifest"}
+
+// MixedContentProbe returns the http:// subresources of an https page.
+func (sigurlx *Sigurlx) MixedContentProbe(parsedURL *url.URL, res Response) ([]string, error) {
+	var mixedContent []string
+
+	if parsedURL.Scheme != "https" {
+		return mixedContent, nil
+	}
+
+	seen := make(map[string]bool)
+
+	for _, tag := range mixedContentTagRegex.FindAllStringSubmatch(string(res.Body), -1) {
+		attributes := parseAttributes(tag[0])
+
+		var resource string
+
+		switch strings.ToLower(tag[1]) {
+		case "link":
+			rel := strings.ToLower(attributes["rel"])
+
+			for _, loaded := range loadedLinkRels {
+				if strings.Contains(rel, loaded) {
+					resource = attributes["href"]
+
+					break
+				}
+			}
+		case "object":
+			resource = attributes["data"]
+		default:
+			resource = attributes["src"]
+		}
+
+		resource = strings.TrimSpace(resource)
+
+		if !strings.HasPrefix(strings.ToLower(resource), "http://") || seen[resource] {
+			continue
+		}
+
+		seen[resource] = true
+		mixedContent = append(mixedContent, resource)
+	}
+
+	return mixedContent, nil
+}
@@ -0,0 +1,150 @@
+package sigurlx
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type nucleiTemplate struct {
+	ID       string          `yaml:"id"`
+	Info     nucleiInfo      `yaml:"info"`
+	Requests []nucleiRequest `yaml:"requests"`
+}
+
+type nucleiInfo struct {
+	Name     string `yaml:"name"`
+	Author   string `yaml:"author"`
+	Severity string `yaml:"severity"`
+	Tags     string `yaml:"tags"`
+}
+
+type nucleiRequest struct {
+	Method            string          `yaml:"method"`
+	Path              []string        `yaml:"path"`
+	MatchersCondition string          `yaml:"matchers-condition"`
+	Matchers          []nucleiMatcher `yaml:"matchers"`
+}
+
+type nucleiMatcher struct {
+	Type  string   `yaml:"type"`
+	Part  string   `yaml:"part"`
+	Words []string `yaml:"words"`
+}
+
+var nucleiIDRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// NucleiTemplate builds a template that replays the reflected param with all
+// of its reflected characters and matches on them coming back in the body.
+func (sigurlx *Sigurlx) NucleiTemplate(result Result, param ReflectedParam) ([]byte, error) {
+	template, err := sigurlx.nucleiTemplate(result, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(template)
+}
+
+func (sigurlx *Sigurlx) nucleiTemplate(result Result, param ReflectedParam) (template nucleiTemplate, err error) {
+	parsedURL, err := url.Parse(result.URL)
+	if err != nil {
+		return template, err
+	}
+
+	token := "aprefix" + strings.Join(param.Characters, "") + "asuffix"
+
+	query := parsedURL.Query()
+
+	path := parsedURL.EscapedPath()
+
+	if param.Location == "matrix" {
+		value := getMatrixQuery(path).Get(param.Param) + sigurlx.Options.PayloadPrefix + token + sigurlx.Options.PayloadSuffix
+		path = setMatrixParam(parsedURL, param.Param, value).EscapedPath()
+	} else {
+		query.Set(param.Param, query.Get(param.Param)+sigurlx.Options.PayloadPrefix+token+sigurlx.Options.PayloadSuffix)
+	}
+
+	if path == "" {
+		path = "/"
+	}
+
+	matchers := []nucleiMatcher{
+		{Type: "word", Part: "body", Words: []string{token}},
+		{Type: "word", Part: "header", Words: []string{"text/html"}},
+	}
+
+	if param.Location == "set-cookie" {
+		matchers = []nucleiMatcher{{Type: "word", Part: "header", Words: []string{token}}}
+	}
+
+	requestPath := "{{RootURL}}" + path + "?" + query.Encode()
+
+	name := fmt.Sprintf("%s%s %s %s", parsedURL.Host, parsedURL.Path, param.Param, param.Location)
+
+	// the same param reflects on the same path of other URLs, or elsewhere
+	hash := sha256.Sum256([]byte(param.Location + " " + requestPath))
+
+	template = nucleiTemplate{
+		ID: "sigurlx-reflected-" + strings.Trim(nucleiIDRegex.ReplaceAllString(strings.ToLower(name), "-"), "-") + "-" + hex.EncodeToString(hash[:4]),
+		Info: nucleiInfo{
+			Name:     "Reflected parameter " + param.Param + " on " + parsedURL.Host + parsedURL.Path,
+			Author:   "sigurlx",
+			Severity: "medium",
+			Tags:     "xss,reflected",
+		},
+		Requests: []nucleiRequest{
+			{
+				Method:            "GET",
+				Path:              []string{requestPath},
+				MatchersCondition: "and",
+				Matchers:          matchers,
+			},
+		},
+	}
+
+	return template, nil
+}
+
+// WriteNucleiTemplates writes a template per reflected param into directory.
+func (sigurlx *Sigurlx) WriteNucleiTemplates(directory string, results Results) error {
+	if err := os.MkdirAll(directory, os.ModePerm); err != nil {
+		return err
+	}
+
+	written := make(map[string]bool)
+
+	for _, result := range results {
+		for _, param := range result.ReflectedParams {
+			template, err := sigurlx.nucleiTemplate(result, param)
+			if err != nil {
+				return err
+			}
+
+			// the ID hashes the request, same ID same template
+			if written[template.ID] {
+				continue
+			}
+
+			written[template.ID] = true
+
+			raw, err := yaml.Marshal(template)
+			if err != nil {
+				return err
+			}
+
+			if err = ioutil.WriteFile(filepath.Join(directory, template.ID+".yaml"), raw, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package sigurlx
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// savedResponse is the JSON layout of a saved response. Header values may be
+// a string or a list of strings.
+type savedResponse struct {
+	URL        string                     `json:"url"`
+	StatusCode int                        `json:"status_code"`
+	Headers    map[string]json.RawMessage `json:"headers"`
+	Body       string                     `json:"body"`
+}
+
+// AnalyzeDirectory runs the body checks over the responses saved in
+// directory, without sending any request. Files ending in .json hold a
+// savedResponse, any other file holds the URL on its first line followed by
+// the raw HTTP response. Files that fail to read or parse get a Result with
+// the Error, keyed by the file when the URL is unknown.
+func (sigurlx *Sigurlx) AnalyzeDirectory(directory string) (Results, error) {
+	var results Results
+
+	var files []string
+
+	err := filepath.Walk(directory, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !info.IsDir() {
+			files = append(files, path)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return results, err
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		var result Result
+
+		// one bad file of a large corpus shouldn't discard the rest of it
+		URL, res, err := readSavedResponse(file)
+		if err != nil {
+			if URL == "" {
+				URL = file
+			}
+
+			err = fmt.Errorf("%s: %w", file, err)
+		} else {
+			result, err = sigurlx.AnalyzeBody(URL, res)
+		}
+
+		if err != nil {
+			result.URL = URL
+			result.Error = err.Error()
+			result.ErrorKind = ErrorKind(err)
+		}
+
+		sigurlx.stamp(&result)
+
+		if sigurlx.Options.OnResult != nil {
+			sigurlx.Options.OnResult(result)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func readSavedResponse(file string) (URL string, res Response, err error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return URL, res, err
+	}
+
+	if strings.HasSuffix(file, ".json") {
+		var saved savedResponse
+
+		if err = json.Unmarshal(raw, &saved); err != nil {
+			return URL, res, err
+		}
+
+		res.StatusCode = saved.StatusCode
+		if res.StatusCode == 0 {
+			res.StatusCode = http.StatusOK
+		}
+
+		res.Headers = make(map[string][]string)
+
+		for header, value := range saved.Headers {
+			key := http.CanonicalHeaderKey(header)
+
+			var values []string
+
+			if err := json.Unmarshal(value, &values); err == nil {
+				res.Headers[key] = values
+
+				continue
+			}
+
+			var single string
+
+			if err := json.Unmarshal(value, &single); err != nil {
+				return URL, res, fmt.Errorf("invalid value for header %s", header)
+			}
+
+			res.Headers[key] = []string{single}
+		}
+
+		res.Body = []byte(saved.Body)
+
+		URL = saved.URL
+	} else {
+		reader := bufio.NewReader(bytes.NewReader(raw))
+
+		if URL, err = reader.ReadString('\n'); err != nil {
+			return URL, res, err
+		}
+
+		URL = strings.TrimSpace(URL)
+
+		response, err := http.ReadResponse(reader, nil)
+		if err != nil {
+			return URL, res, err
+		}
+		defer response.Body.Close()
+
+		res.StatusCode = response.StatusCode
+		res.Headers = response.Header
+
+		if res.Body, err = ioutil.ReadAll(response.Body); err != nil {
+			return URL, res, err
+		}
+	}
+
+	res.ContentType = res.GetHeaderPart("Content-Type", ";")
+	res.ContentLength = utf8.RuneCountInString(string(res.Body))
+	res.RedirectLocation = http.Header(res.Headers).Get("Location")
+
+	return URL, res, nil
+}
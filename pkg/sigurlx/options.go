@@ -2,18 +2,129 @@ package sigurlx
 
 import (
 	"math/rand"
+	"net/http"
+	"regexp"
 	"time"
 )
 
+// DefaultReflectContentTypes are the content types reflection is tested on
+// when Options.ReflectContentTypes is empty.
+var DefaultReflectContentTypes = []string{"text/html", "application/*", "text/*"}
+
 type Options struct {
+	CaptureRaw          bool
+	CacheBust           bool
+	CaseInsensitive     bool
+	CategoryMatch       bool
+	ClientCert          string
+	ClientKey           string
+	Checks              map[string]CheckGate
+	CRLF                bool
+	CSP                 bool
+	ConditionalCache    string
+	ConnectTo           string
+	DebugDisclosure     bool
+	Dedup               bool
+	DOM                 bool
+	DOMCategories       []string
+	DecodePasses        int
+	Delay               int
+	DirectoryListing    bool
+	DNSCacheTTL         int
+	EncodedReflection   bool
+	ExtendedCategories  bool
+	FilterBodyRegex     *regexp.Regexp
 	FollowRedirects     bool
+	ForceChecks         bool
+	FragmentParams      bool
+	FullJSON            bool
 	FollowHostRedirects bool
+	GraphQL             bool
+	HeaderEnv           map[string]string
+	HandlerPayloads     bool
+	HostProbe           bool
+	HPP                 bool
+	HTTP10              bool
 	HTTPProxy           string
+	HTTPProxies         []string
+	IdleConnTimeout     int
+	IgnoreParams        []string
+	InlineHandlers      bool
+	KeepAlive           int
+	Lenient             bool
+	LocalAddr           string
+	JWT                 bool
+	Links               bool
+	MatrixParams        bool
+	MatchBodyRegex      *regexp.Regexp
+	MatchStatus         []int
+	MaxParamsPerURL     int
+	MaxPerCategory      int
+	MaxRunTime          int
+	MixedContent        bool
+	ParamSource         bool
+	ParamsFiles         []string
+	PathReflection      bool
+	PayloadPrefix       string
+	PayloadSuffix       string
+	MethodProbe         bool
+	OnResult            func(Result)
+	OpenRedirect        bool
+	OutputFields        []string
+	PerHostConcurrency  int
+	PrettyJSON          bool
+	ProbeScheme         bool
+	ProxyRotation       string
+	ReflectMaxBodySize  int
+	RawHeaders          []string
+	ReflectContentTypes []string
+	ReflectParams       []string
+	ReflectionCache     bool
+	RequestBody         string
+	RequestContentType  string
+	RequestTemplate     *RequestTemplate
+	ResponseCache       string
+	ResponseCacheTTL    int
+	RulesFile           string
+	ScanID              string
+	SchemeDiff          bool
+	Scope               []string
+	Scoring             map[string]int
+	SecurityHeaders     bool
+	Shuffle             bool
+	SignRequest         func(*http.Request) error
+	Secrets             bool
+	SkipThirdParty      bool
+	SNI                 string
+	StreamBodySize      int
+	SQLi                bool
+	SRI                 bool
+	Swagger             bool
+	Tags                []string
+	Threads             int
 	Timeout             int
 	UserAgent           string
+	VCS                 bool
+	Verify              bool
 }
 
 func (options *Options) Parse() {
+	if options.Threads <= 0 {
+		options.Threads = 20
+	}
+
+	if options.KeepAlive <= 0 {
+		options.KeepAlive = 30
+	}
+
+	if options.IdleConnTimeout <= 0 {
+		options.IdleConnTimeout = 90
+	}
+
+	if len(options.ReflectContentTypes) == 0 {
+		options.ReflectContentTypes = append([]string(nil), DefaultReflectContentTypes...)
+	}
+
 	if options.UserAgent == "" {
 		payload := []string{
 			"Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/74.0.3729.169 Safari/537.36",
@@ -1,23 +1,49 @@
 package sigurlx
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/drsigned/sigurlx/pkg/params"
 )
 
 func (sigurlx *Sigurlx) initParams() error {
-	raw, err := ioutil.ReadFile(params.File())
-	if err != nil {
-		return err
+	files := sigurlx.Options.ParamsFiles
+	if len(files) == 0 {
+		files = []string{params.File()}
 	}
 
-	if err = json.Unmarshal(raw, &sigurlx.Params); err != nil {
-		return err
+	for _, file := range files {
+		raw, err := ioutil.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		var loaded []CommonVulnParam
+
+		if err = json.Unmarshal(raw, &loaded); err != nil {
+			return err
+		}
+
+		for i := range loaded {
+			if loaded[i].Source == "" {
+				loaded[i].Source = file
+			}
+
+			if err = sigurlx.compileParamMatch(loaded[i]); err != nil {
+				return err
+			}
+		}
+
+		sigurlx.Params = append(sigurlx.Params, loaded...)
 	}
 
 	return nil
@@ -26,10 +52,24 @@ func (sigurlx *Sigurlx) initParams() error {
 func (sigurlx *Sigurlx) CommonVulnParamsProbe(query url.Values) ([]CommonVulnParam, error) {
 	var commonVulnParams []CommonVulnParam
 
+	tested := sigurlx.testedParams(query)
+
 	for parameter := range query {
+		if !tested[parameter] {
+			continue
+		}
+
 		for i := range sigurlx.Params {
-			if strings.ToLower(sigurlx.Params[i].Param) == strings.ToLower(parameter) {
-				commonVulnParams = append(commonVulnParams, sigurlx.Params[i])
+			if sigurlx.matchParam(sigurlx.Params[i], parameter) {
+				commonVulnParam := sigurlx.Params[i]
+
+				if !sigurlx.Options.ParamSource {
+					commonVulnParam.RuleID = ""
+					commonVulnParam.Source = ""
+					commonVulnParam.Match = ""
+				}
+
+				commonVulnParams = append(commonVulnParams, commonVulnParam)
 
 				break
 			}
@@ -39,6 +79,42 @@ func (sigurlx *Sigurlx) CommonVulnParamsProbe(query url.Values) ([]CommonVulnPar
 	return commonVulnParams, nil
 }
 
+func (sigurlx *Sigurlx) compileParamMatch(param CommonVulnParam) error {
+	switch param.Match {
+	case "", "exact", "prefix", "suffix", "contains":
+		return nil
+	case "regex":
+		regex, err := newRegex(`(?i)` + param.Param)
+		if err != nil {
+			return fmt.Errorf("invalid regex for param %s: %w", param.Param, err)
+		}
+
+		sigurlx.paramRegexes[param.Param] = regex
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown match mode %s for param %s", param.Match, param.Param)
+}
+
+// matchParam compares case-insensitively, by default the whole name.
+func (sigurlx *Sigurlx) matchParam(param CommonVulnParam, parameter string) bool {
+	rule, parameter := strings.ToLower(param.Param), strings.ToLower(parameter)
+
+	switch param.Match {
+	case "prefix":
+		return strings.HasPrefix(parameter, rule)
+	case "suffix":
+		return strings.HasSuffix(parameter, rule)
+	case "contains":
+		return strings.Contains(parameter, rule)
+	case "regex":
+		return sigurlx.paramRegexes[param.Param].MatchString(parameter)
+	}
+
+	return rule == parameter
+}
+
 func (sigurlx *Sigurlx) ReflectedParamsProbe(parsedURL *url.URL, query url.Values, res Response) ([]ReflectedParam, error) {
 	var reflectedParams []ReflectedParam
 
@@ -47,32 +123,148 @@ func (sigurlx *Sigurlx) ReflectedParamsProbe(parsedURL *url.URL, query url.Value
 		return reflectedParams, err
 	}
 
+	tested := sigurlx.testedParams(query)
+
 	if len(reflected) > 0 {
-		for _, parameter := range reflected {
-			characters := []string{"\"", "'", "<", ">", "/"}
+		for _, r := range reflected {
+			if !sigurlx.shouldReflect(r.param) || !tested[r.param] {
+				continue
+			}
 
-			var reflectedCharacters []string
+			param := r.param
 
-			for _, char := range characters {
-				wasReflected, err := sigurlx.checkAppend(parsedURL, query, parameter, "aprefix"+char+"asuffix")
-				if err != nil {
-					continue
-				}
+			reflectedCharacters, raw, cacheable, sniffable := sigurlx.testCharacters(func(token string) (bool, Response, error) {
+				return sigurlx.checkAppend(parsedURL, query, param, token)
+			})
+
+			var context string
 
-				if wasReflected {
-					reflectedCharacters = append(reflectedCharacters, char)
+			if sigurlx.Options.HandlerPayloads && containsString(reflectedCharacters, "\"") {
+				injectable, res, err := sigurlx.checkHandlerInjection(parsedURL, query, r.param)
+				if err == nil && injectable {
+					context = "attribute-injectable"
+					raw = res.Raw
+					cacheable = cacheable || isCacheable(res)
 				}
 			}
 
-			if len(reflectedCharacters) > 2 {
-				reflectedParams = append(reflectedParams, ReflectedParam{Param: parameter, Characters: reflectedCharacters})
+			if len(reflectedCharacters) > 2 || context != "" {
+				reflectedParams = append(reflectedParams, ReflectedParam{
+					Param:      r.param,
+					Characters: reflectedCharacters,
+					Encoding:   r.encoding,
+					Context:    context,
+					Cacheable:  cacheable,
+					Sniffable:  sniffable,
+					Raw:        raw,
+				})
 			}
 		}
 	}
 
+	reflectedParams = append(reflectedParams, sigurlx.setCookieReflections(parsedURL, query, res)...)
+
 	return reflectedParams, nil
 }
 
+// testCharacters appends each special character, between a prefix and a
+// suffix, to a param with appendToken and returns those that come back, with
+// the evidence of the last one and whether any response was cacheable or
+// sniffable.
+func (sigurlx *Sigurlx) testCharacters(appendToken func(token string) (bool, Response, error)) (reflectedCharacters []string, raw string, cacheable, sniffable bool) {
+	for _, char := range []string{"\"", "'", "<", ">", "/"} {
+		wasReflected, res, err := appendToken("aprefix" + char + "asuffix")
+		if err != nil {
+			continue
+		}
+
+		// a second, different token rules out cached or random content
+		if wasReflected && sigurlx.Options.Verify {
+			wasReflected, res, err = appendToken(verifyToken(char))
+			if err != nil {
+				continue
+			}
+		}
+
+		if wasReflected {
+			reflectedCharacters = append(reflectedCharacters, char)
+			raw = res.Raw
+			cacheable = cacheable || isCacheable(res)
+			sniffable = sniffable || isSniffable(res)
+		}
+	}
+
+	return reflectedCharacters, raw, cacheable, sniffable
+}
+
+// reflectionTestedParams lists the params ReflectedParamsProbe tests.
+func (sigurlx *Sigurlx) reflectionTestedParams(query url.Values) []string {
+	var params []string
+
+	tested := sigurlx.testedParams(query)
+
+	for param := range query {
+		if sigurlx.shouldReflect(param) && tested[param] {
+			params = append(params, param)
+		}
+	}
+
+	sort.Strings(params)
+
+	return params
+}
+
+func (sigurlx *Sigurlx) shouldReflect(param string) bool {
+	if len(sigurlx.Options.ReflectParams) == 0 {
+		return true
+	}
+
+	for _, reflectParam := range sigurlx.Options.ReflectParams {
+		if strings.EqualFold(reflectParam, param) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getQuery parses the query of parsedURL. With Options.DecodePasses set, the
+// values are decoded value by value instead of unescaping the whole URL, which
+// would split values holding an encoded & or =.
+func (sigurlx *Sigurlx) getQuery(parsedURL *url.URL) (url.Values, error) {
+	if sigurlx.Options.DecodePasses <= 0 {
+		return getQuery(parsedURL.String())
+	}
+
+	query, err := url.ParseQuery(parsedURL.RawQuery)
+	if err != nil {
+		return query, err
+	}
+
+	return sigurlx.decodeQuery(query), nil
+}
+
+// decodeQuery undoes up to Options.DecodePasses more layers of encoding of
+// the values of query, e.g %2527 -> %27 -> '.
+func (sigurlx *Sigurlx) decodeQuery(query url.Values) url.Values {
+	for param, values := range query {
+		for i, value := range values {
+			for pass := 0; pass < sigurlx.Options.DecodePasses; pass++ {
+				decoded, err := url.QueryUnescape(value)
+				if err != nil || decoded == value {
+					break
+				}
+
+				value = decoded
+			}
+
+			query[param][i] = value
+		}
+	}
+
+	return query
+}
+
 func getQuery(URL string) (url.Values, error) {
 	var query url.Values
 
@@ -94,52 +286,181 @@ func getQuery(URL string) (url.Values, error) {
 	return query, nil
 }
 
-func (sigurlx *Sigurlx) checkReflection(URL string, query url.Values, res Response) ([]string, error) {
-	var reflected []string
+type reflection struct {
+	param    string
+	encoding string
+}
 
-	if res.IsEmpty() {
-		res, _ = sigurlx.DoHTTP(URL)
+func getFragmentQuery(fragment string) url.Values {
+	// SPA routes look like "/search?q=x", plain fragments like "q=x&y=z"
+	if i := strings.Index(fragment, "?"); i >= 0 {
+		fragment = fragment[i+1:]
+	} else if !strings.Contains(fragment, "=") {
+		return url.Values{}
 	}
 
-	if res.StatusCode >= http.StatusMultipleChoices && res.StatusCode < http.StatusBadRequest {
-		return reflected, nil
+	query, _ := url.ParseQuery(fragment)
+
+	return query
+}
+
+func listParams(query url.Values, source string) []Param {
+	var params []Param
+
+	for name := range query {
+		params = append(params, Param{Name: name, Source: source})
 	}
 
-	if res.ContentType != "" && !strings.Contains(res.ContentType, "html") {
+	sort.Slice(params, func(i, j int) bool {
+		return params[i].Name < params[j].Name
+	})
+
+	return params
+}
+
+func (sigurlx *Sigurlx) checkReflection(URL string, query url.Values, res Response) ([]reflection, error) {
+	var reflected []reflection
+
+	if res.IsEmpty() {
+		res, _ = sigurlx.DoHTTP(URL)
+	}
+
+	if !sigurlx.isReflectable(res) {
 		return reflected, nil
 	}
 
 	for param, value := range query {
 		for _, v := range value {
-			if !strings.Contains(string(res.Body), v) {
+			if strings.Contains(string(res.Body), v) {
+				reflected = append(reflected, reflection{param: param})
+
 				continue
 			}
 
-			reflected = append(reflected, param)
+			if !sigurlx.Options.EncodedReflection {
+				continue
+			}
+
+			if encoding, ok := findEncodedReflection(string(res.Body), v); ok {
+				reflected = append(reflected, reflection{param: param, encoding: encoding})
+			}
 		}
 	}
 
 	return reflected, nil
 }
 
-func (sigurlx *Sigurlx) checkAppend(parsedURL *url.URL, query url.Values, param, suffix string) (bool, error) {
-	val := query.Get(param)
+// requestWithParam requests parsedURL with param set to value, leaving
+// parsedURL as it was. The other params are sent with their raw values, not
+// the ones of the probes' query, which Options.DecodePasses decodes.
+func (sigurlx *Sigurlx) requestWithParam(parsedURL *url.URL, param, value string) (Response, error) {
+	requestURL := *parsedURL
+	requestURL.RawQuery = setRawParam(parsedURL.RawQuery, param, value)
 
-	query.Set(param, val+suffix)
-	parsedURL.RawQuery = query.Encode()
+	return sigurlx.DoHTTP(requestURL.String())
+}
 
-	reflected, err := sigurlx.checkReflection(parsedURL.String(), query, Response{})
-	if err != nil {
-		return false, err
+// setRawParam replaces the values of param in rawQuery, where it first
+// appears, leaving the other params exactly as they were.
+func setRawParam(rawQuery, param string, values ...string) string {
+	var pairs []string
+
+	replaced := false
+
+	for _, pair := range strings.Split(rawQuery, "&") {
+		if pair == "" {
+			continue
+		}
+
+		key := pair
+		if i := strings.Index(pair, "="); i >= 0 {
+			key = pair[:i]
+		}
+
+		if unescaped, err := url.QueryUnescape(key); err != nil || unescaped != param {
+			pairs = append(pairs, pair)
+
+			continue
+		}
+
+		if !replaced {
+			for _, value := range values {
+				pairs = append(pairs, url.QueryEscape(param)+"="+url.QueryEscape(value))
+			}
+
+			replaced = true
+		}
 	}
 
-	for _, r := range reflected {
-		if r == param {
-			return true, nil
+	if !replaced {
+		for _, value := range values {
+			pairs = append(pairs, url.QueryEscape(param)+"="+url.QueryEscape(value))
 		}
 	}
 
-	query.Set(param, val)
+	return strings.Join(pairs, "&")
+}
+
+// verifyToken returns a token for char that differs on every call, for
+// Options.Verify to confirm reflections with.
+func verifyToken(char string) string {
+	return "v" + strconv.FormatInt(rand.Int63(), 36) + char + "v"
+}
+
+func (sigurlx *Sigurlx) checkAppend(parsedURL *url.URL, query url.Values, param, token string) (bool, Response, error) {
+	value := query.Get(param) + sigurlx.Options.PayloadPrefix + token + sigurlx.Options.PayloadSuffix
+
+	res, err := sigurlx.requestWithParam(parsedURL, param, value)
+	if err != nil {
+		return false, res, err
+	}
+
+	if !sigurlx.isReflectable(res) {
+		return false, res, nil
+	}
+
+	// only the token has to survive, the padding around it may be stripped.
+	// An encoded token doesn't count, the characters it is there to test for
+	// didn't come back, encoded reflections are for checkReflection to find.
+	return strings.Contains(string(res.Body), token), res, nil
+}
+
+func (sigurlx *Sigurlx) isReflectable(res Response) bool {
+	if res.StatusCode >= http.StatusMultipleChoices && res.StatusCode < http.StatusBadRequest {
+		return false
+	}
+
+	return sigurlx.reflectableContentType(res)
+}
+
+// isSniffable reports whether a browser would render the response as HTML,
+// either because it says so or because it can be sniffed into it.
+func isSniffable(res Response) bool {
+	if strings.Contains(res.ContentType, "text/html") {
+		return true
+	}
+
+	nosniff := strings.EqualFold(strings.TrimSpace(res.GetHeaderPart("X-Content-Type-Options", ",")), "nosniff")
+
+	return res.ContentType == "" && !nosniff
+}
+
+func findEncodedReflection(body, value string) (string, bool) {
+	encodings := []struct {
+		name    string
+		encoded string
+	}{
+		{"base64", base64.StdEncoding.EncodeToString([]byte(value))},
+		{"base64", base64.RawURLEncoding.EncodeToString([]byte(value))},
+		{"url", url.QueryEscape(value)},
+		{"url", url.PathEscape(value)},
+	}
+
+	for _, encoding := range encodings {
+		if encoding.encoded != value && strings.Contains(body, encoding.encoded) {
+			return encoding.name, true
+		}
+	}
 
-	return false, nil
+	return "", false
 }
@@ -0,0 +1,43 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+type PathReflection struct {
+	Characters []string `json:"characters,omitempty"`
+}
+
+func (sigurlx *Sigurlx) PathReflectionProbe(parsedURL *url.URL) (*PathReflection, error) {
+	if !sigurlx.checkPathReflection(parsedURL, "sigurlxpath") {
+		return nil, nil
+	}
+
+	pathReflection := &PathReflection{}
+
+	for _, char := range []string{"\"", "'", "<", ">"} {
+		if sigurlx.checkPathReflection(parsedURL, "aprefix"+char+"asuffix") {
+			pathReflection.Characters = append(pathReflection.Characters, char)
+		}
+	}
+
+	return pathReflection, nil
+}
+
+func (sigurlx *Sigurlx) checkPathReflection(parsedURL *url.URL, payload string) bool {
+	injectedURL := *parsedURL
+	injectedURL.Path = strings.TrimSuffix(parsedURL.Path, "/") + "/" + payload
+	injectedURL.RawPath = ""
+
+	res, err := sigurlx.DoHTTP(injectedURL.String())
+	if err != nil {
+		return false
+	}
+
+	if !sigurlx.isReflectable(res) {
+		return false
+	}
+
+	return strings.Contains(string(res.Body), payload)
+}
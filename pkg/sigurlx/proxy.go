@@ -0,0 +1,66 @@
+package sigurlx
+
+import (
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+)
+
+type proxyFunc func(*http.Request) (*url.URL, error)
+
+// proxies returns Options.HTTPProxy and Options.HTTPProxies, the pool
+// requests are spread across.
+func (sigurlx *Sigurlx) proxies() []string {
+	var proxies []string
+
+	if sigurlx.Options.HTTPProxy != "" {
+		proxies = append(proxies, sigurlx.Options.HTTPProxy)
+	}
+
+	for _, proxy := range sigurlx.Options.HTTPProxies {
+		if proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+
+	return proxies
+}
+
+// newProxyFunc picks a proxy per request, in turn or at random when rotation
+// is "random". It returns nil when there are no proxies.
+func newProxyFunc(proxies []string, rotation string) (proxyFunc, error) {
+	if len(proxies) == 0 {
+		return nil, nil
+	}
+
+	if rotation != "" && rotation != "round-robin" && rotation != "random" {
+		return nil, fmt.Errorf("unknown proxy rotation: %s", rotation)
+	}
+
+	proxyURLs := make([]*url.URL, len(proxies))
+
+	for i, proxy := range proxies {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy %s: %w", proxy, err)
+		}
+
+		proxyURLs[i] = proxyURL
+	}
+
+	if len(proxyURLs) == 1 {
+		return http.ProxyURL(proxyURLs[0]), nil
+	}
+
+	var next uint64
+
+	return func(_ *http.Request) (*url.URL, error) {
+		if rotation == "random" {
+			return proxyURLs[rand.Intn(len(proxyURLs))], nil
+		}
+
+		return proxyURLs[(atomic.AddUint64(&next, 1)-1)%uint64(len(proxyURLs))], nil
+	}, nil
+}
@@ -0,0 +1,268 @@
+package sigurlx
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// rawTransport writes requests itself, over a connection of their own, for
+// what net/http can't be told to do: send HTTP/1.0, for old servers that
+// choke on keep-alive or chunked bodies, or send headers in an exact order
+// and casing, as WAFs fingerprint the canonical, sorted ones of Go.
+type rawTransport struct {
+	dial      dialContext
+	tlsConfig *tls.Config
+	proxy     proxyFunc
+	proto     string
+	headers   []string
+}
+
+func (transport *rawTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	addr := canonicalAddr(req.URL)
+
+	target := addr
+
+	var proxy *url.URL
+
+	if transport.proxy != nil {
+		var err error
+
+		if proxy, err = transport.proxy(req); err != nil {
+			return nil, err
+		}
+
+		target = canonicalAddr(proxy)
+	}
+
+	conn, err := transport.dial(req.Context(), "tcp", target)
+	if err != nil {
+		return nil, err
+	}
+
+	// the client cancels through the context, the connection has to follow
+	done := make(chan struct{})
+	closeOnce := &sync.Once{}
+	closeConn := func() {
+		closeOnce.Do(func() {
+			close(done)
+			conn.Close()
+		})
+	}
+
+	go func() {
+		select {
+		case <-req.Context().Done():
+			closeConn()
+		case <-done:
+		}
+	}()
+
+	res, err := transport.roundTrip(req, conn, addr, proxy != nil)
+	if err != nil {
+		closeConn()
+
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+
+		return nil, err
+	}
+
+	res.Body = &rawBody{ReadCloser: res.Body, close: closeConn}
+
+	return res, nil
+}
+
+func (transport *rawTransport) roundTrip(req *http.Request, conn net.Conn, addr string, proxied bool) (*http.Response, error) {
+	var state *tls.ConnectionState
+
+	reader := bufio.NewReader(conn)
+
+	if req.URL.Scheme == "https" {
+		if proxied {
+			if err := connectTunnel(conn, reader, addr); err != nil {
+				return nil, err
+			}
+		}
+
+		config := transport.tlsConfig.Clone()
+
+		if config.ServerName == "" {
+			config.ServerName = req.URL.Hostname()
+		}
+
+		tlsConn := tls.Client(conn, config)
+		if err := tlsConn.Handshake(); err != nil {
+			return nil, err
+		}
+
+		connectionState := tlsConn.ConnectionState()
+		state = &connectionState
+
+		conn = tlsConn
+		reader = bufio.NewReader(conn)
+	}
+
+	var body []byte
+
+	if req.Body != nil {
+		var err error
+
+		if body, err = ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+
+		req.Body.Close()
+	}
+
+	target := req.URL.RequestURI()
+
+	// plain http proxies take the absolute URL
+	if proxied && req.URL.Scheme == "http" {
+		target = req.URL.String()
+	}
+
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	header := req.Header.Clone()
+
+	if body != nil {
+		header.Set("Content-Length", strconv.Itoa(len(body)))
+	}
+
+	if transport.proto != "HTTP/1.0" && header.Get("Connection") == "" {
+		header.Set("Connection", "close")
+	}
+
+	buf := &bytes.Buffer{}
+
+	fmt.Fprintf(buf, "%s %s %s\r\n", req.Method, target, transport.proto)
+
+	if err := writeRawHeaders(buf, header, host, transport.headers); err != nil {
+		return nil, err
+	}
+
+	buf.WriteString("\r\n")
+	buf.Write(body)
+
+	if _, err := conn.Write(buf.Bytes()); err != nil {
+		return nil, err
+	}
+
+	res, err := http.ReadResponse(reader, req)
+	if err != nil {
+		return nil, err
+	}
+
+	res.TLS = state
+
+	return res, nil
+}
+
+// writeRawHeaders writes the raw headers in their order and casing, either as
+// "Name: value" or as "Name", for the value the request has, then the ones
+// they don't name as net/http would. Host comes first unless they name it.
+func writeRawHeaders(w io.Writer, header http.Header, host string, raw []string) error {
+	header = header.Clone()
+	header.Set("Host", host)
+
+	written := make(map[string]bool)
+
+	for _, line := range raw {
+		name, _, _ := splitRawHeader(line)
+		written[http.CanonicalHeaderKey(name)] = true
+	}
+
+	if !written["Host"] {
+		if _, err := fmt.Fprintf(w, "Host: %s\r\n", host); err != nil {
+			return err
+		}
+	}
+
+	for _, line := range raw {
+		name, value, ok := splitRawHeader(line)
+
+		values := []string{value}
+
+		if !ok {
+			values = header[http.CanonicalHeaderKey(name)]
+		}
+
+		for _, value := range values {
+			if _, err := fmt.Fprintf(w, "%s: %s\r\n", name, value); err != nil {
+				return err
+			}
+		}
+	}
+
+	written["Host"] = true
+
+	return header.WriteSubset(w, written)
+}
+
+// splitRawHeader splits "Name: value", ok being false for a bare "Name".
+func splitRawHeader(line string) (name, value string, ok bool) {
+	i := strings.Index(line, ":")
+	if i < 0 {
+		return strings.TrimSpace(line), "", false
+	}
+
+	return strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:]), true
+}
+
+func connectTunnel(conn net.Conn, reader *bufio.Reader, addr string) error {
+	if _, err := fmt.Fprintf(conn, "CONNECT %s HTTP/1.0\r\nHost: %s\r\n\r\n", addr, addr); err != nil {
+		return err
+	}
+
+	res, err := http.ReadResponse(reader, &http.Request{Method: http.MethodConnect})
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("proxy CONNECT to %s failed: %s", addr, res.Status)
+	}
+
+	return nil
+}
+
+func canonicalAddr(u *url.URL) string {
+	if port := u.Port(); port != "" {
+		return u.Host
+	}
+
+	port := "80"
+	if strings.EqualFold(u.Scheme, "https") {
+		port = "443"
+	}
+
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// rawBody closes the connection along with the body, the server won't keep
+// it open anyway.
+type rawBody struct {
+	io.ReadCloser
+	close func()
+}
+
+func (body *rawBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.close()
+
+	return err
+}
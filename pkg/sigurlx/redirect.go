@@ -0,0 +1,105 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type OpenRedirect struct {
+	Param    string `json:"param,omitempty"`
+	Payload  string `json:"payload,omitempty"`
+	Location string `json:"location,omitempty"` // location, refresh or href
+	Scheme   string `json:"scheme,omitempty"`   // the dangerous scheme, if any
+	Raw      string `json:"raw,omitempty"`
+}
+
+type openRedirectPayload struct {
+	Payload string
+	Scheme  string
+	// matches the payload starting an href, src or action attribute
+	Target *regexp.Regexp
+}
+
+// openRedirectPayloads are tried on each param, the javascript: and data:
+// ones turning an open redirect into XSS when they land in an href.
+var openRedirectPayloads = []openRedirectPayload{
+	newOpenRedirectPayload("https://sigurlx.example/", ""),
+	newOpenRedirectPayload("javascript:alert(1)//sigurlx", "javascript"),
+	newOpenRedirectPayload("data:text/html,sigurlx", "data"),
+}
+
+func newOpenRedirectPayload(payload, scheme string) openRedirectPayload {
+	return openRedirectPayload{
+		Payload: payload,
+		Scheme:  scheme,
+		Target:  regexp.MustCompile(`(?i)\b(?:href|src|action|formaction)\s*=\s*["']?` + regexp.QuoteMeta(payload)),
+	}
+}
+
+var refreshURLRegex = regexp.MustCompile(`(?i)url\s*=\s*['"]?(.*)`)
+
+// OpenRedirectProbe sets each param to the open redirect payloads, looking
+// for them at the start of the Location or Refresh header or of an href,
+// src or action attribute in the body, i.e unsanitized redirect targets.
+func (sigurlx *Sigurlx) OpenRedirectProbe(parsedURL *url.URL, query url.Values) ([]OpenRedirect, error) {
+	var openRedirects []OpenRedirect
+
+	params := make([]string, 0, len(query))
+
+	for param := range sigurlx.testedParams(query) {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		for _, payload := range openRedirectPayloads {
+			res, err := sigurlx.requestWithParam(parsedURL, param, payload.Payload)
+			if err != nil {
+				continue
+			}
+
+			location := redirectedTo(res, payload)
+			if location == "" {
+				continue
+			}
+
+			openRedirects = append(openRedirects, OpenRedirect{
+				Param:    param,
+				Payload:  payload.Payload,
+				Location: location,
+				Scheme:   payload.Scheme,
+				Raw:      res.Raw,
+			})
+		}
+	}
+
+	return openRedirects, nil
+}
+
+// redirectedTo returns where res points the browser to payload, if it does.
+func redirectedTo(res Response, payload openRedirectPayload) string {
+	if hasPrefixFold(res.RedirectLocation, payload.Payload) {
+		return "location"
+	}
+
+	for _, refresh := range res.Headers["Refresh"] {
+		if match := refreshURLRegex.FindStringSubmatch(refresh); match != nil && hasPrefixFold(match[1], payload.Payload) {
+			return "refresh"
+		}
+	}
+
+	if payload.Target.Match(res.Body) {
+		return "href"
+	}
+
+	return ""
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	s = strings.TrimSpace(s)
+
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
@@ -0,0 +1,69 @@
+package sigurlx
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+)
+
+type reflectionCache struct {
+	mutex    *sync.Mutex
+	verdicts map[string][]ReflectedParam
+}
+
+// reflectionSignature is the same for urls of one template, e.g /item?id=1
+// and /item?id=2, whose params are expected to reflect the same way.
+func reflectionSignature(parsedURL *url.URL, query url.Values) string {
+	names := make([]string, 0, len(query))
+
+	for name := range query {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return parsedURL.Scheme + "://" + parsedURL.Host + parsedURL.Path + "?" + strings.Join(names, "&")
+}
+
+func (cache *reflectionCache) get(signature string) ([]ReflectedParam, bool) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	verdict, ok := cache.verdicts[signature]
+
+	return verdict, ok
+}
+
+func (cache *reflectionCache) set(signature string, verdict []ReflectedParam) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.verdicts[signature] = verdict
+}
+
+// cachedReflectedParamsProbe runs ReflectedParamsProbe once per signature.
+// Reused verdicts drop Raw, as that exchange belongs to another url.
+func (sigurlx *Sigurlx) cachedReflectedParamsProbe(parsedURL *url.URL, query url.Values, res Response) ([]ReflectedParam, error) {
+	signature := reflectionSignature(parsedURL, query)
+
+	if verdict, ok := sigurlx.reflections.get(signature); ok {
+		var reflectedParams []ReflectedParam
+
+		for _, reflectedParam := range verdict {
+			reflectedParam.Raw = ""
+			reflectedParams = append(reflectedParams, reflectedParam)
+		}
+
+		return reflectedParams, nil
+	}
+
+	reflectedParams, err := sigurlx.ReflectedParamsProbe(parsedURL, query, res)
+	if err != nil {
+		return reflectedParams, err
+	}
+
+	sigurlx.reflections.set(signature, reflectedParams)
+
+	return reflectedParams, nil
+}
@@ -7,6 +7,16 @@ import (
 
 var mutex = &sync.Mutex{}
 
+// caseInsensitive wraps pattern in a case-insensitive group when asked to, so
+// that it still composes with other patterns.
+func caseInsensitive(pattern string, insensitive bool) string {
+	if !insensitive {
+		return pattern
+	}
+
+	return `(?i:` + pattern + `)`
+}
+
 func newRegex(pattern string) (*regexp.Regexp, error) {
 	mutex.Lock()
 	defer mutex.Unlock()
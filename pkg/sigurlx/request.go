@@ -1,29 +1,92 @@
 package sigurlx
 
 import (
+	"bytes"
 	"crypto/tls"
+	"fmt"
+	"io"
 	"io/ioutil"
+	"math/rand"
 	"net"
 	"net/http"
-	"net/url"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 	"unicode/utf8"
 )
 
 func (sigurlx *Sigurlx) initClient() error {
+	dialer := &net.Dialer{
+		Timeout:   time.Duration(sigurlx.Options.Timeout) * time.Second,
+		KeepAlive: time.Duration(sigurlx.Options.KeepAlive) * time.Second,
+	}
+
+	if sigurlx.Options.LocalAddr != "" {
+		IP := net.ParseIP(sigurlx.Options.LocalAddr)
+		if IP == nil {
+			return fmt.Errorf("invalid local address: %s", sigurlx.Options.LocalAddr)
+		}
+
+		dialer.LocalAddr = &net.TCPAddr{IP: IP}
+	}
+
+	dial := dialer.DialContext
+
+	if sigurlx.Options.DNSCacheTTL > 0 {
+		dial = newDNSCache(time.Duration(sigurlx.Options.DNSCacheTTL) * time.Second).wrap(dial)
+	}
+
+	if sigurlx.Options.ConnectTo != "" {
+		dial = connectTo(dial, sigurlx.Options.ConnectTo)
+	}
+
 	tr := &http.Transport{
-		DialContext: (&net.Dialer{
-			Timeout:   time.Duration(sigurlx.Options.Timeout) * time.Second,
-			KeepAlive: time.Second,
-		}).DialContext,
+		DialContext:     dial,
+		IdleConnTimeout: time.Duration(sigurlx.Options.IdleConnTimeout) * time.Second,
+		// every thread may be hitting the same host, keep their connections
+		MaxIdleConnsPerHost: sigurlx.Options.Threads,
 		TLSClientConfig: &tls.Config{
 			InsecureSkipVerify: true,
+			MinVersion:         tls.VersionTLS10,
+			CipherSuites:       cipherSuites(),
+			// empty, the Host of each URL is sent
+			ServerName: sigurlx.Options.SNI,
 		},
 	}
 
-	if sigurlx.Options.HTTPProxy != "" {
-		if proxyURL, err := url.Parse(sigurlx.Options.HTTPProxy); err == nil {
-			tr.Proxy = http.ProxyURL(proxyURL)
+	if sigurlx.Options.ClientCert != "" || sigurlx.Options.ClientKey != "" {
+		certificate, err := tls.LoadX509KeyPair(sigurlx.Options.ClientCert, sigurlx.Options.ClientKey)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+
+		tr.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	proxy, err := newProxyFunc(sigurlx.proxies(), sigurlx.Options.ProxyRotation)
+	if err != nil {
+		return err
+	}
+
+	tr.Proxy = proxy
+
+	var transport http.RoundTripper = tr
+
+	if sigurlx.Options.HTTP10 || len(sigurlx.Options.RawHeaders) > 0 {
+		proto := "HTTP/1.1"
+
+		if sigurlx.Options.HTTP10 {
+			proto = "HTTP/1.0"
+		}
+
+		transport = &rawTransport{
+			dial:      dial,
+			tlsConfig: tr.TLSClientConfig,
+			proxy:     proxy,
+			proto:     proto,
+			headers:   sigurlx.Options.RawHeaders,
 		}
 	}
 
@@ -50,7 +113,7 @@ func (sigurlx *Sigurlx) initClient() error {
 
 	sigurlx.Client = &http.Client{
 		Timeout:       time.Duration(sigurlx.Options.Timeout) * time.Second,
-		Transport:     tr,
+		Transport:     transport,
 		CheckRedirect: re,
 	}
 
@@ -58,47 +121,183 @@ func (sigurlx *Sigurlx) initClient() error {
 }
 
 func (sigurlx *Sigurlx) DoHTTP(URL string) (Response, error) {
+	return sigurlx.DoHTTPRequest(http.MethodGet, URL, nil, nil)
+}
+
+func (sigurlx *Sigurlx) DoHTTPRequest(method, URL string, body []byte, headers map[string]string) (Response, error) {
 	var response Response
 
-	res, err := sigurlx.httpRequest(URL, http.MethodGet, sigurlx.Client)
+	var length int
+
+	release := sigurlx.acquireHost(URL)
+	defer release()
+
+	res, err := sigurlx.httpRequest(URL, method, body, headers, sigurlx.Client)
 	if err != nil {
 		return response, err
 	}
 
 	response.Headers = res.Header.Clone()
+	response.TLS = res.TLS
 
 	// websockets don't have a readable body
 	if res.StatusCode != http.StatusSwitchingProtocols {
 		// always read the full body so we can re-use the tcp connection
-		if response.Body, err = ioutil.ReadAll(res.Body); err != nil {
+		if sigurlx.Options.StreamBodySize > 0 {
+			if response.Body, response.scan, length, err = sigurlx.readBody(res.Body); err != nil {
+				return response, err
+			}
+		} else if response.Body, err = ioutil.ReadAll(res.Body); err != nil {
 			return response, err
 		}
 	}
 
+	atomic.AddInt64(&sigurlx.metrics.bytes, int64(len(response.Body)))
+
 	if err := res.Body.Close(); err != nil {
 		return response, err
 	}
 
+	if sigurlx.Options.CaptureRaw {
+		response.Raw = rawExchange(res, body, response.Body)
+	}
+
+	// scans run on the decoded body so that non UTF-8 pages still match.
+	// Streamed bodies are scanned as they are read, only the part kept for the
+	// other checks is decoded.
+	response.Body = decodeBody(response.Body, res.Header.Get("Content-Type"))
+
+	if response.scan == nil {
+		length = utf8.RuneCount(response.Body)
+	}
+
 	response.StatusCode = res.StatusCode
 	response.ContentType = response.GetHeaderPart("Content-Type", ";")
-	response.ContentLength = utf8.RuneCountInString(string(response.Body))
-	response.RedirectLocation = response.GetHeaderPart("Location", ";")
+	response.ContentLength = length
+	// unlike in Content-Type, a ";" in Location is part of the value
+	response.RedirectLocation = res.Header.Get("Location")
 
 	return response, nil
 }
 
-func (sigurlx *Sigurlx) httpRequest(URL string, method string, client *http.Client) (res *http.Response, err error) {
-	req, err := http.NewRequest(method, URL, nil)
+func (sigurlx *Sigurlx) httpRequest(URL string, method string, body []byte, headers map[string]string, client *http.Client) (res *http.Response, err error) {
+	var reader io.Reader
+
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(sigurlx.ctx, method, URL, reader)
 	if err != nil {
 		return res, err
 	}
 
 	req.Header.Set("User-Agent", sigurlx.Options.UserAgent)
 
+	// the random cache busting param would never hit the response cache
+	cacheURL := req.URL.String()
+
+	// set before the custom headers so that those still win
+	if sigurlx.Options.CacheBust {
+		cacheBust(req)
+	}
+
+	for _, headers := range []map[string]string{sigurlx.headers, headers} {
+		for header, value := range headers {
+			// net/http sends req.Host, not the header
+			if strings.EqualFold(header, "Host") {
+				req.Host = value
+
+				continue
+			}
+
+			req.Header.Set(header, value)
+		}
+	}
+
+	var cachePath string
+
+	// keyed before signing, signatures tend to change on every request
+	if sigurlx.Options.ResponseCache != "" {
+		cachePath = sigurlx.responseCachePath(req, cacheURL, body)
+	}
+
+	if sigurlx.Options.SignRequest != nil {
+		if err = sigurlx.Options.SignRequest(req); err != nil {
+			return res, err
+		}
+	}
+
+	if cachePath != "" {
+		if cached, ok := sigurlx.loadCachedResponse(cachePath, req); ok {
+			return cached, nil
+		}
+	}
+
+	start := time.Now()
+
 	res, err = client.Do(req)
+
+	statusCode := 0
+	if res != nil {
+		statusCode = res.StatusCode
+	}
+
+	sigurlx.metrics.observeRequest(time.Since(start), statusCode, err)
+
 	if err != nil {
 		return res, err
 	}
 
+	// websockets don't have a readable body to keep
+	if cachePath != "" && res.StatusCode != http.StatusSwitchingProtocols {
+		return sigurlx.storeCachedResponse(cachePath, res)
+	}
+
 	return res, nil
 }
+
+const maxRawBodyLength = 2048
+
+func rawExchange(res *http.Response, requestBody, responseBody []byte) string {
+	var raw bytes.Buffer
+
+	if dump, err := httputil.DumpRequest(res.Request, false); err == nil {
+		raw.Write(dump)
+	}
+
+	if len(requestBody) > 0 {
+		raw.Write(requestBody)
+		raw.WriteString("\r\n\r\n")
+	}
+
+	fmt.Fprintf(&raw, "%s %s\r\n", res.Proto, res.Status)
+	res.Header.Write(&raw)
+	raw.WriteString("\r\n")
+
+	if len(responseBody) > maxRawBodyLength {
+		responseBody = responseBody[:maxRawBodyLength]
+	}
+
+	raw.Write(responseBody)
+
+	return raw.String()
+}
+
+const cacheBustParam = "sigurlxcb"
+
+// cacheBust asks caches in the way, e.g CDNs, for a fresh response and makes
+// the URL unique so that those ignoring the headers miss too.
+func cacheBust(req *http.Request) {
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("Pragma", "no-cache")
+
+	// appended rather than re-encoded, to keep the order of the params
+	buster := cacheBustParam + "=" + strconv.FormatInt(rand.Int63(), 36)
+
+	if req.URL.RawQuery == "" {
+		req.URL.RawQuery = buster
+	} else {
+		req.URL.RawQuery += "&" + buster
+	}
+}
@@ -1,6 +1,7 @@
 package sigurlx
 
 import (
+	"crypto/tls"
 	"reflect"
 	"strings"
 )
@@ -11,8 +12,12 @@ type Response struct {
 	ContentLength    int
 	RedirectLocation string
 	Headers          map[string][]string
+	TLS              *tls.ConnectionState
 	Body             []byte
 	Raw              string
+
+	// set when the body was streamed, see readBody
+	scan *streamScan
 }
 
 func (response Response) IsEmpty() bool {
@@ -0,0 +1,153 @@
+package sigurlx
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cachedResponse is a response as kept in Options.ResponseCache, the body
+// as it came over the wire.
+type cachedResponse struct {
+	URL         string      `json:"url"`
+	Method      string      `json:"method"`
+	StatusCode  int         `json:"status_code"`
+	Header      http.Header `json:"header"`
+	Body        []byte      `json:"body"`
+	TLSVersion  uint16      `json:"tls_version,omitempty"`
+	CipherSuite uint16      `json:"cipher_suite,omitempty"`
+	Time        time.Time   `json:"time"`
+}
+
+func (sigurlx *Sigurlx) initResponseCache() error {
+	if sigurlx.Options.ResponseCache == "" {
+		return nil
+	}
+
+	return os.MkdirAll(sigurlx.Options.ResponseCache, os.ModePerm)
+}
+
+// responseCachePath is the file the response to req is cached in, keyed by
+// its method, URL, Host, headers and body, as probes such as -host-probe send
+// the same URL with different headers.
+func (sigurlx *Sigurlx) responseCachePath(req *http.Request, URL string, body []byte) string {
+	hash := sha256.New()
+
+	hash.Write([]byte(req.Method + " " + URL + "\nHost: " + req.Host + "\n"))
+
+	headers := make([]string, 0, len(req.Header))
+
+	for header := range req.Header {
+		headers = append(headers, header)
+	}
+
+	sort.Strings(headers)
+
+	for _, header := range headers {
+		hash.Write([]byte(header + ": " + strings.Join(req.Header[header], ", ") + "\n"))
+	}
+
+	hash.Write([]byte("\n"))
+	hash.Write(body)
+
+	return filepath.Join(sigurlx.Options.ResponseCache, hex.EncodeToString(hash.Sum(nil))+".json")
+}
+
+// loadCachedResponse returns the cached response to req, unless there is
+// none or it is older than Options.ResponseCacheTTL.
+func (sigurlx *Sigurlx) loadCachedResponse(path string, req *http.Request) (*http.Response, bool) {
+	raw, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var cached cachedResponse
+
+	if err := json.Unmarshal(raw, &cached); err != nil {
+		return nil, false
+	}
+
+	if ttl := time.Duration(sigurlx.Options.ResponseCacheTTL) * time.Second; ttl > 0 && time.Since(cached.Time) > ttl {
+		return nil, false
+	}
+
+	res := &http.Response{
+		Status:        http.StatusText(cached.StatusCode),
+		StatusCode:    cached.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        cached.Header,
+		Body:          ioutil.NopCloser(bytes.NewReader(cached.Body)),
+		ContentLength: int64(len(cached.Body)),
+		Request:       req,
+	}
+
+	if cached.TLSVersion != 0 {
+		res.TLS = &tls.ConnectionState{Version: cached.TLSVersion, CipherSuite: cached.CipherSuite}
+	}
+
+	return res, true
+}
+
+// storeCachedResponse writes res to path, handing back a response whose body
+// can still be read. Bodies over Options.StreamBodySize are left uncached, so
+// that they can still be streamed.
+func (sigurlx *Sigurlx) storeCachedResponse(path string, res *http.Response) (*http.Response, error) {
+	reader := res.Body
+
+	if sigurlx.Options.StreamBodySize > 0 {
+		reader = ioutil.NopCloser(io.LimitReader(res.Body, int64(sigurlx.Options.StreamBodySize)+1))
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return res, err
+	}
+
+	if sigurlx.Options.StreamBodySize > 0 && len(body) > sigurlx.Options.StreamBodySize {
+		res.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(body), res.Body), res.Body}
+
+		return res, nil
+	}
+
+	if err := res.Body.Close(); err != nil {
+		return res, err
+	}
+
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	cached := cachedResponse{
+		URL:        res.Request.URL.String(),
+		Method:     res.Request.Method,
+		StatusCode: res.StatusCode,
+		Header:     res.Header,
+		Body:       body,
+		Time:       time.Now(),
+	}
+
+	if res.TLS != nil {
+		cached.TLSVersion = res.TLS.Version
+		cached.CipherSuite = res.TLS.CipherSuite
+	}
+
+	JSON, err := json.Marshal(cached)
+	if err != nil {
+		return res, err
+	}
+
+	return res, ioutil.WriteFile(path, JSON, 0644)
+}
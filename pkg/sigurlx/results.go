@@ -2,36 +2,162 @@ package sigurlx
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"path"
 	"strings"
 )
 
+type Param struct {
+	Name   string `json:"name,omitempty"`
+	Source string `json:"source,omitempty"`
+}
+
 type CommonVulnParam struct {
-	Param string   `json:"param,omitempty"`
-	Risks []string `json:"risks,omitempty"`
+	Param  string   `json:"param,omitempty"`
+	Risks  []string `json:"risks,omitempty"`
+	RuleID string   `json:"rule_id,omitempty"`
+	Source string   `json:"source,omitempty"`
+	Match  string   `json:"match,omitempty"`
 }
 
 type ReflectedParam struct {
 	Param      string   `json:"param,omitempty"`
 	Characters []string `json:"characters,omitempty"`
+	Encoding   string   `json:"encoding,omitempty"`
+	Context    string   `json:"context,omitempty"`
+	Location   string   `json:"location,omitempty"` // empty for the body
+	Cacheable  bool     `json:"cacheable,omitempty"`
+	Sniffable  bool     `json:"sniffable,omitempty"`
+	Raw        string   `json:"raw,omitempty"`
 }
 
 type Result struct {
+	ScanID           string            `json:"scan_id,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
 	URL              string            `json:"url,omitempty"`
+	FinalURL         string            `json:"final_url,omitempty"`
+	Host             string            `json:"host,omitempty"`
+	Error            string            `json:"error,omitempty"`
+	ErrorKind        string            `json:"error_kind,omitempty"`
 	Category         string            `json:"category,omitempty"`
+	CategoryMatch    string            `json:"category_match,omitempty"`
+	Type             string            `json:"type,omitempty"`
+	ThirdParty       bool              `json:"third_party,omitempty"`
+	Score            int               `json:"score,omitempty"`
 	StatusCode       int               `json:"status_code,omitempty"`
 	ContentType      string            `json:"content_type,omitempty"`
 	ContentLength    int               `json:"content_length,omitempty"`
+	BodyTruncated    bool              `json:"body_truncated,omitempty"` // only DOM and secret scans saw the whole body
 	RedirectLocation string            `json:"redirect_location,omitempty"`
+	BodyMatch        bool              `json:"body_match,omitempty"`
+	NotModified      bool              `json:"not_modified,omitempty"`
+	TLSVersion       string            `json:"tls_version,omitempty"`
+	TLSCipherSuite   string            `json:"tls_cipher_suite,omitempty"`
+	WeakTLS          bool              `json:"weak_tls,omitempty"`
+	AllowedMethods   []string          `json:"allowed_methods,omitempty"`
+	MethodOverride   []string          `json:"method_override,omitempty"`
+	HostInjection    []HostInjection   `json:"host_injection,omitempty"`
+	SchemeDiff       *SchemeDiff       `json:"scheme_diff,omitempty"`
+	CSPIssues        []string          `json:"csp_issues,omitempty"`
+	MissingHeaders   []string          `json:"missing_headers,omitempty"`
+	MixedContent     []string          `json:"mixed_content,omitempty"`
+	MissingSRI       []string          `json:"missing_sri,omitempty"`
+	DebugDisclosure  []string          `json:"debug_disclosure,omitempty"`
+	Raw              string            `json:"raw,omitempty"`
+	Params           []Param           `json:"params,omitempty"`
+	TestedParams     []string          `json:"tested_params,omitempty"`
 	CommonVulnParams []CommonVulnParam `json:"common_vuln_params,omitempty"`
 	ReflectedParams  []ReflectedParam  `json:"reflected_params,omitempty"`
+	HPP              []HPP             `json:"hpp,omitempty"`
+	SQLi             []SQLi            `json:"sqli,omitempty"`
+	CRLF             []ReflectedParam  `json:"crlf,omitempty"`
+	OpenRedirect     []OpenRedirect    `json:"open_redirect,omitempty"`
+	JWTFindings      []JWTFinding      `json:"jwt_findings,omitempty"`
+	PathReflection   *PathReflection   `json:"path_reflection,omitempty"`
 	DOM              []string          `json:"dom,omitempty"`
+	InlineHandlers   []string          `json:"inline_handlers,omitempty"`
+	Secrets          []string          `json:"secrets,omitempty"`
+	DirectoryListing bool              `json:"directory_listing,omitempty"`
+	ExposedVCS       []string          `json:"exposed_vcs,omitempty"`
+	ListedEntries    []string          `json:"listed_entries,omitempty"`
+	Links            []string          `json:"links,omitempty"`
+	UploadCandidate  bool              `json:"upload_candidate,omitempty"`
+	GraphQL          *GraphQL          `json:"graphql,omitempty"`
+	SwaggerEndpoints []SwaggerEndpoint `json:"swagger_endpoints,omitempty"`
 }
 
 type Results []Result
 
+func (result Result) HasFindings() bool {
+	return len(result.CommonVulnParams) > 0 ||
+		len(result.ReflectedParams) > 0 ||
+		len(result.SQLi) > 0 ||
+		len(result.CRLF) > 0 ||
+		result.SchemeDiff != nil ||
+		len(result.OpenRedirect) > 0 ||
+		len(result.JWTFindings) > 0 ||
+		result.PathReflection != nil ||
+		len(result.DOM) > 0 ||
+		len(result.Secrets) > 0 ||
+		result.DirectoryListing ||
+		len(result.ExposedVCS) > 0 ||
+		result.UploadCandidate ||
+		(result.GraphQL != nil && result.GraphQL.Introspection) ||
+		result.WeakTLS ||
+		len(result.MethodOverride) > 0 ||
+		len(result.HostInjection) > 0 ||
+		len(result.MissingSRI) > 0 ||
+		len(result.MixedContent) > 0 ||
+		len(result.InlineHandlers) > 0 ||
+		len(result.CSPIssues) > 0 ||
+		len(result.MissingHeaders) > 0 ||
+		len(result.DebugDisclosure) > 0
+}
+
+func WriteResult(w io.Writer, result Result) error {
+	return writeResult(w, result, json.Marshal)
+}
+
+func WriteResultIndented(w io.Writer, result Result) error {
+	return writeResult(w, result, indent(json.MarshalIndent))
+}
+
+func WriteResultFull(w io.Writer, result Result) error {
+	return writeResult(w, result, MarshalFull)
+}
+
+func WriteResultFullIndented(w io.Writer, result Result) error {
+	return writeResult(w, result, indent(MarshalFullIndent))
+}
+
+func writeResult(w io.Writer, result Result, marshal func(interface{}) ([]byte, error)) error {
+	JSON, err := marshal(result)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(JSON))
+
+	return err
+}
+
+func indent(marshal func(interface{}, string, string) ([]byte, error)) func(interface{}) ([]byte, error) {
+	return func(v interface{}) ([]byte, error) {
+		return marshal(v, "", "\t")
+	}
+}
+
 func (results Results) SaveToJSON(PATH string) error {
+	return results.saveToJSON(PATH, indent(json.MarshalIndent))
+}
+
+func (results Results) SaveToFullJSON(PATH string) error {
+	return results.saveToJSON(PATH, indent(MarshalFullIndent))
+}
+
+func (results Results) saveToJSON(PATH string, marshal func(interface{}) ([]byte, error)) error {
 	if PATH != "" {
 		if _, err := os.Stat(PATH); os.IsNotExist(err) {
 			directory, filename := path.Split(PATH)
@@ -49,7 +175,7 @@ func (results Results) SaveToJSON(PATH string) error {
 			}
 		}
 
-		JSON, err := json.MarshalIndent(results, "", "\t")
+		JSON, err := marshal(results)
 		if err != nil {
 			return err
 		}
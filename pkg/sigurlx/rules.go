@@ -0,0 +1,102 @@
+package sigurlx
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+
+	"gopkg.in/yaml.v2"
+)
+
+// rules is the layout of Options.RulesFile. Everything in it is added to the
+// built-in categories, params, secrets and DOM patterns. Its checks replace
+// the Options.Checks gates of the same name.
+type rules struct {
+	Categories []struct {
+		Name  string `yaml:"name"`
+		Regex string `yaml:"regex"`
+	} `yaml:"categories"`
+	Params []struct {
+		ID    string   `yaml:"id"`
+		Param string   `yaml:"param"`
+		Match string   `yaml:"match"`
+		Risks []string `yaml:"risks"`
+	} `yaml:"params"`
+	Secrets []struct {
+		Name            string `yaml:"name"`
+		Regex           string `yaml:"regex"`
+		CaseInsensitive bool   `yaml:"case_insensitive"`
+	} `yaml:"secrets"`
+	DOM struct {
+		Sources         []string `yaml:"sources"`
+		Sinks           []string `yaml:"sinks"`
+		CaseInsensitive bool     `yaml:"case_insensitive"`
+	} `yaml:"dom"`
+	Checks map[string]CheckGate `yaml:"checks"`
+}
+
+type customCategory struct {
+	name  string
+	regex *regexp.Regexp
+}
+
+func (sigurlx *Sigurlx) initRules() error {
+	if sigurlx.Options.RulesFile == "" {
+		return nil
+	}
+
+	raw, err := ioutil.ReadFile(sigurlx.Options.RulesFile)
+	if err != nil {
+		return err
+	}
+
+	var r rules
+
+	if err = yaml.Unmarshal(raw, &r); err != nil {
+		return fmt.Errorf("invalid rules file: %w", err)
+	}
+
+	for _, category := range r.Categories {
+		regex, err := newRegex(category.Regex)
+		if err != nil {
+			return fmt.Errorf("invalid regex for category %s: %w", category.Name, err)
+		}
+
+		sigurlx.customCategories = append(sigurlx.customCategories, customCategory{name: category.Name, regex: regex})
+	}
+
+	for _, param := range r.Params {
+		commonVulnParam := CommonVulnParam{
+			Param:  param.Param,
+			Risks:  param.Risks,
+			RuleID: param.ID,
+			Source: sigurlx.Options.RulesFile,
+			Match:  param.Match,
+		}
+
+		if err = sigurlx.compileParamMatch(commonVulnParam); err != nil {
+			return err
+		}
+
+		sigurlx.Params = append(sigurlx.Params, commonVulnParam)
+	}
+
+	for _, secret := range r.Secrets {
+		regex, err := newRegex(caseInsensitive(secret.Regex, secret.CaseInsensitive || sigurlx.Options.CaseInsensitive))
+		if err != nil {
+			return fmt.Errorf("invalid regex for secret %s: %w", secret.Name, err)
+		}
+
+		sigurlx.secretRegexes[secret.Name] = regex
+	}
+
+	for _, source := range r.DOM.Sources {
+		sigurlx.domSources = append(sigurlx.domSources, caseInsensitive(source, r.DOM.CaseInsensitive))
+	}
+
+	for _, sink := range r.DOM.Sinks {
+		sigurlx.domSinks = append(sigurlx.domSinks, caseInsensitive(sink, r.DOM.CaseInsensitive))
+	}
+
+	return sigurlx.addChecks(r.Checks)
+}
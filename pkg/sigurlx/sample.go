@@ -0,0 +1,41 @@
+package sigurlx
+
+import "sync"
+
+type categorySamples struct {
+	mutex   *sync.Mutex
+	taken   map[string]int
+	skipped map[string]int
+}
+
+// take reports whether another URL of category fits in Options.MaxPerCategory,
+// counting it as skipped if not.
+func (samples *categorySamples) take(category string, max int) bool {
+	samples.mutex.Lock()
+	defer samples.mutex.Unlock()
+
+	if samples.taken[category] >= max {
+		samples.skipped[category]++
+
+		return false
+	}
+
+	samples.taken[category]++
+
+	return true
+}
+
+// SkippedPerCategory returns how many URLs of each category were left out
+// for going over Options.MaxPerCategory.
+func (sigurlx *Sigurlx) SkippedPerCategory() map[string]int {
+	sigurlx.samples.mutex.Lock()
+	defer sigurlx.samples.mutex.Unlock()
+
+	skipped := make(map[string]int, len(sigurlx.samples.skipped))
+
+	for category, count := range sigurlx.samples.skipped {
+		skipped[category] = count
+	}
+
+	return skipped
+}
@@ -0,0 +1,232 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+var sarifRules = []sarifRule{
+	{ID: "dom", ShortDescription: sarifMessage{Text: "DOM XSS source or sink"}},
+	{ID: "secret", ShortDescription: sarifMessage{Text: "Exposed secret"}},
+	{ID: "reflected-param", ShortDescription: sarifMessage{Text: "Reflected parameter"}},
+	{ID: "sqli", ShortDescription: sarifMessage{Text: "SQL injection candidate"}},
+	{ID: "crlf", ShortDescription: sarifMessage{Text: "CRLF injection / response splitting"}},
+	{ID: "scheme-diff", ShortDescription: sarifMessage{Text: "URL answers differently over HTTP than over HTTPS"}},
+	{ID: "open-redirect", ShortDescription: sarifMessage{Text: "Open redirect, or XSS through a javascript:/data: redirect target"}},
+	{ID: "path-reflection", ShortDescription: sarifMessage{Text: "Reflected URL path"}},
+	{ID: "common-vuln-param", ShortDescription: sarifMessage{Text: "Commonly vulnerable parameter"}},
+	{ID: "jwt", ShortDescription: sarifMessage{Text: "JWT passed in the URL"}},
+	{ID: "debug-disclosure", ShortDescription: sarifMessage{Text: "Debug information disclosure"}},
+	{ID: "exposed-vcs", ShortDescription: sarifMessage{Text: "Exposed VCS or environment file"}},
+	{ID: "directory-listing", ShortDescription: sarifMessage{Text: "Directory listing exposed"}},
+	{ID: "graphql-introspection", ShortDescription: sarifMessage{Text: "GraphQL introspection enabled"}},
+	{ID: "upload-candidate", ShortDescription: sarifMessage{Text: "Potential file upload endpoint"}},
+	{ID: "host-injection", ShortDescription: sarifMessage{Text: "Spoofed Host header reflected"}},
+	{ID: "method-override", ShortDescription: sarifMessage{Text: "HTTP method override honored"}},
+	{ID: "missing-sri", ShortDescription: sarifMessage{Text: "Third party resource without SRI"}},
+	{ID: "inline-handler", ShortDescription: sarifMessage{Text: "Inline event handler or javascript: URL in html"}},
+	{ID: "mixed-content", ShortDescription: sarifMessage{Text: "HTTPS page loading http:// subresources"}},
+	{ID: "csp-issue", ShortDescription: sarifMessage{Text: "Missing or permissive Content-Security-Policy"}},
+	{ID: "missing-header", ShortDescription: sarifMessage{Text: "Missing security header"}},
+	{ID: "weak-tls", ShortDescription: sarifMessage{Text: "Weak TLS version or cipher suite"}},
+}
+
+func WriteSARIF(w io.Writer, results Results) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "sigurlx",
+				InformationURI: "https://github.com/drsigned/sigurlx",
+				Rules:          sarifRules,
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	for _, result := range results {
+		add := func(ruleID, level, message string) {
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  ruleID,
+				Level:   level,
+				Message: sarifMessage{Text: message},
+				Locations: []sarifLocation{
+					{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: result.URL}}},
+				},
+			})
+		}
+
+		for _, dom := range result.DOM {
+			add("dom", "error", fmt.Sprintf("DOM XSS source or sink: %s", dom))
+		}
+
+		for _, secret := range result.Secrets {
+			add("secret", "error", secret)
+		}
+
+		for _, param := range result.ReflectedParams {
+			if param.Location == "set-cookie" {
+				add("reflected-param", "error", fmt.Sprintf("parameter %s is reflected in Set-Cookie", param.Param))
+
+				continue
+			}
+
+			add("reflected-param", "error", fmt.Sprintf("parameter %s is reflected with characters %s", param.Param, strings.Join(param.Characters, " ")))
+		}
+
+		for _, sqli := range result.SQLi {
+			add("sqli", "error", fmt.Sprintf("parameter %s looks injectable (%s)", sqli.Param, sqli.Technique))
+		}
+
+		for _, CRLF := range result.CRLF {
+			add("crlf", "error", fmt.Sprintf("parameter %s injects into response headers (%s)", CRLF.Param, CRLF.Context))
+		}
+
+		if result.SchemeDiff != nil {
+			add("scheme-diff", "warning", fmt.Sprintf("http version differs in %s (status %d)", strings.Join(result.SchemeDiff.Differences, ", "), result.SchemeDiff.StatusCode))
+		}
+
+		for _, openRedirect := range result.OpenRedirect {
+			if openRedirect.Scheme != "" {
+				add("open-redirect", "error", fmt.Sprintf("parameter %s sets a %s: redirect target (%s)", openRedirect.Param, openRedirect.Scheme, openRedirect.Location))
+			} else {
+				add("open-redirect", "warning", fmt.Sprintf("parameter %s sets the redirect target (%s)", openRedirect.Param, openRedirect.Location))
+			}
+		}
+
+		if result.PathReflection != nil {
+			add("path-reflection", "error", fmt.Sprintf("URL path is reflected with characters %s", strings.Join(result.PathReflection.Characters, " ")))
+		}
+
+		for _, param := range result.CommonVulnParams {
+			add("common-vuln-param", "warning", fmt.Sprintf("parameter %s is commonly vulnerable to %s", param.Param, strings.Join(param.Risks, ", ")))
+		}
+
+		for _, finding := range result.JWTFindings {
+			level := "warning"
+
+			if containsString(finding.Issues, "alg-none") {
+				level = "error"
+			}
+
+			message := fmt.Sprintf("parameter %s holds a %s JWT", finding.Param, finding.Alg)
+
+			if len(finding.Issues) > 0 {
+				message += " (" + strings.Join(finding.Issues, ", ") + ")"
+			}
+
+			add("jwt", level, message)
+		}
+
+		for _, disclosure := range result.DebugDisclosure {
+			add("debug-disclosure", "warning", disclosure)
+		}
+
+		for _, exposed := range result.ExposedVCS {
+			add("exposed-vcs", "error", fmt.Sprintf("%s is accessible", exposed))
+		}
+
+		if result.DirectoryListing {
+			add("directory-listing", "warning", fmt.Sprintf("directory listing exposes %d entries", len(result.ListedEntries)))
+		}
+
+		if result.GraphQL != nil && result.GraphQL.Introspection {
+			add("graphql-introspection", "warning", "GraphQL introspection is enabled")
+		}
+
+		if result.UploadCandidate {
+			add("upload-candidate", "note", "URL looks like a file upload endpoint")
+		}
+
+		for _, injection := range result.HostInjection {
+			add("host-injection", "error", fmt.Sprintf("spoofed %s header is reflected in the %s", injection.Header, injection.Location))
+		}
+
+		for _, header := range result.MethodOverride {
+			add("method-override", "warning", fmt.Sprintf("method override header %s is honored", header))
+		}
+
+		for _, resource := range result.MissingSRI {
+			add("missing-sri", "note", fmt.Sprintf("%s is loaded without SRI", resource))
+		}
+
+		for _, handler := range result.InlineHandlers {
+			add("inline-handler", "note", handler)
+		}
+
+		for _, resource := range result.MixedContent {
+			add("mixed-content", "warning", fmt.Sprintf("%s is loaded over http", resource))
+		}
+
+		for _, issue := range result.CSPIssues {
+			add("csp-issue", "note", issue)
+		}
+
+		for _, header := range result.MissingHeaders {
+			add("missing-header", "note", fmt.Sprintf("missing %s header", header))
+		}
+
+		if result.WeakTLS {
+			add("weak-tls", "note", fmt.Sprintf("weak TLS negotiated: %s %s", result.TLSVersion, result.TLSCipherSuite))
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "\t")
+
+	return encoder.Encode(sarifLog{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs:    []sarifRun{run},
+	})
+}
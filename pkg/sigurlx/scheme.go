@@ -0,0 +1,132 @@
+package sigurlx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var schemeRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
+
+func hasScheme(URL string) bool {
+	return schemeRegex.MatchString(URL)
+}
+
+// doSchemeRequest tries https and falls back to http, leaving parsedURL set
+// to the scheme that answered. Only a failure to connect or to do the TLS
+// handshake falls back, any other error is the https answer.
+func (sigurlx *Sigurlx) doSchemeRequest(method string, parsedURL *url.URL, body []byte, headers map[string]string) (res Response, err error) {
+	parsedURL.Scheme = "https"
+
+	if res, err = sigurlx.DoHTTPRequest(method, parsedURL.String(), body, headers); err == nil || !isDialOrTLSError(err) {
+		return res, err
+	}
+
+	parsedURL.Scheme = "http"
+
+	return sigurlx.DoHTTPRequest(method, parsedURL.String(), body, headers)
+}
+
+func isDialOrTLSError(err error) bool {
+	var opError *net.OpError
+	var recordHeaderError tls.RecordHeaderError
+	var certificateError x509.CertificateInvalidError
+	var unknownAuthorityError x509.UnknownAuthorityError
+	var hostnameError x509.HostnameError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return false
+	case errors.As(err, &opError) && opError.Op == "dial":
+		return true
+	case errors.As(err, &recordHeaderError),
+		errors.As(err, &certificateError),
+		errors.As(err, &unknownAuthorityError),
+		errors.As(err, &hostnameError):
+		return true
+	}
+
+	// a plain http server on the port answers the handshake with http
+	return strings.Contains(err.Error(), "tls:") || strings.Contains(err.Error(), "HTTP response to HTTPS client")
+}
+
+// SchemeDiff is how the http version of an https URL answered differently.
+type SchemeDiff struct {
+	StatusCode       int      `json:"status_code,omitempty"`
+	ContentLength    int      `json:"content_length,omitempty"`
+	RedirectLocation string   `json:"redirect_location,omitempty"`
+	Differences      []string `json:"differences,omitempty"` // status, content_length or redirect
+}
+
+// SchemeDiffProbe requests the http version of an https URL, flagging a
+// different status, a content length off by more than 10%, or a different
+// redirect. An http that redirects to https on the same host is the expected
+// upgrade, not a difference.
+func (sigurlx *Sigurlx) SchemeDiffProbe(parsedURL *url.URL, res Response) (*SchemeDiff, error) {
+	httpURL := *parsedURL
+	httpURL.Scheme = "http"
+
+	if httpURL.Port() == "443" {
+		httpURL.Host = httpURL.Hostname()
+	}
+
+	httpRes, err := sigurlx.DoHTTP(httpURL.String())
+	if err != nil {
+		// nothing listens on http
+		return nil, nil
+	}
+
+	if isHTTPSUpgrade(&httpURL, httpRes) {
+		return nil, nil
+	}
+
+	schemeDiff := &SchemeDiff{
+		StatusCode:       httpRes.StatusCode,
+		ContentLength:    httpRes.ContentLength,
+		RedirectLocation: httpRes.RedirectLocation,
+	}
+
+	if httpRes.StatusCode != res.StatusCode {
+		schemeDiff.Differences = append(schemeDiff.Differences, "status")
+	}
+
+	if httpRes.RedirectLocation != res.RedirectLocation {
+		schemeDiff.Differences = append(schemeDiff.Differences, "redirect")
+	}
+
+	if abs(httpRes.ContentLength-res.ContentLength)*10 > res.ContentLength {
+		schemeDiff.Differences = append(schemeDiff.Differences, "content_length")
+	}
+
+	if len(schemeDiff.Differences) == 0 {
+		return nil, nil
+	}
+
+	return schemeDiff, nil
+}
+
+func isHTTPSUpgrade(httpURL *url.URL, res Response) bool {
+	if res.RedirectLocation == "" {
+		return false
+	}
+
+	location, err := httpURL.Parse(res.RedirectLocation)
+	if err != nil {
+		return false
+	}
+
+	return location.Scheme == "https" && strings.EqualFold(location.Hostname(), httpURL.Hostname())
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
@@ -0,0 +1,35 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+func (sigurlx *Sigurlx) isThirdParty(parsedURL *url.URL) bool {
+	if len(sigurlx.Options.Scope) == 0 {
+		return false
+	}
+
+	domain := registeredDomain(parsedURL.Hostname())
+
+	for _, scope := range sigurlx.Options.Scope {
+		if registeredDomain(scope) == domain {
+			return false
+		}
+	}
+
+	return true
+}
+
+func registeredDomain(host string) string {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	domain, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+
+	return domain
+}
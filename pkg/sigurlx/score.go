@@ -0,0 +1,91 @@
+package sigurlx
+
+var DefaultScoring = map[string]int{
+	"dom":                   10,
+	"secret":                10,
+	"exposed_vcs":           10,
+	"reflected_param":       10,
+	"sqli":                  10,
+	"crlf":                  10,
+	"redirect_xss":          10,
+	"path_reflection":       10,
+	"host_injection":        10,
+	"upload_candidate":      5,
+	"graphql_introspection": 5,
+	"common_vuln_param":     5,
+	"jwt":                   5,
+	"open_redirect":         5,
+	"method_override":       5,
+	"debug_disclosure":      5,
+	"directory_listing":     5,
+	"scheme_diff":           5,
+	"weak_tls":              2,
+	"missing_sri":           2,
+	"mixed_content":         2,
+	"csp_issue":             1,
+	"missing_header":        1,
+	"inline_handler":        1,
+}
+
+func (sigurlx *Sigurlx) score(result Result) (score int) {
+	weight := func(finding string) int {
+		if w, ok := sigurlx.Options.Scoring[finding]; ok {
+			return w
+		}
+
+		return DefaultScoring[finding]
+	}
+
+	score += weight("dom") * len(result.DOM)
+	score += weight("secret") * len(result.Secrets)
+	score += weight("exposed_vcs") * len(result.ExposedVCS)
+	score += weight("reflected_param") * len(result.ReflectedParams)
+	score += weight("sqli") * len(result.SQLi)
+	score += weight("crlf") * len(result.CRLF)
+
+	for _, openRedirect := range result.OpenRedirect {
+		// a javascript: or data: target is XSS, not just a redirect
+		if openRedirect.Scheme != "" {
+			score += weight("redirect_xss")
+		} else {
+			score += weight("open_redirect")
+		}
+	}
+
+	score += weight("common_vuln_param") * len(result.CommonVulnParams)
+	score += weight("jwt") * len(result.JWTFindings)
+	score += weight("method_override") * len(result.MethodOverride)
+	score += weight("host_injection") * len(result.HostInjection)
+	score += weight("missing_sri") * len(result.MissingSRI)
+	score += weight("mixed_content") * len(result.MixedContent)
+	score += weight("csp_issue") * len(result.CSPIssues)
+	score += weight("missing_header") * len(result.MissingHeaders)
+	score += weight("inline_handler") * len(result.InlineHandlers)
+	score += weight("debug_disclosure") * len(result.DebugDisclosure)
+
+	if result.PathReflection != nil {
+		score += weight("path_reflection")
+	}
+
+	if result.SchemeDiff != nil {
+		score += weight("scheme_diff")
+	}
+
+	if result.DirectoryListing {
+		score += weight("directory_listing")
+	}
+
+	if result.UploadCandidate {
+		score += weight("upload_candidate")
+	}
+
+	if result.GraphQL != nil && result.GraphQL.Introspection {
+		score += weight("graphql_introspection")
+	}
+
+	if result.WeakTLS {
+		score += weight("weak_tls")
+	}
+
+	return score
+}
@@ -0,0 +1,48 @@
+package sigurlx
+
+import (
+	"regexp"
+	"sort"
+)
+
+var defaultSecretPatterns = map[string]string{
+	"aws access key":  `\b(AKIA|ASIA)[0-9A-Z]{16}\b`,
+	"google api key":  `\bAIza[0-9A-Za-z_-]{35}\b`,
+	"github token":    `\bgh[pousr]_[0-9A-Za-z]{36}\b`,
+	"slack token":     `\bxox[abposr]-[0-9A-Za-z-]{10,48}\b`,
+	"stripe live key": `\b(sk|rk)_live_[0-9A-Za-z]{24,}\b`,
+	"private key":     `-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP) )?PRIVATE KEY( BLOCK)?-----`,
+}
+
+func (sigurlx *Sigurlx) initSecrets() error {
+	sigurlx.secretRegexes = make(map[string]*regexp.Regexp)
+
+	for name, pattern := range defaultSecretPatterns {
+		regex, err := newRegex(caseInsensitive(pattern, sigurlx.Options.CaseInsensitive))
+		if err != nil {
+			return err
+		}
+
+		sigurlx.secretRegexes[name] = regex
+	}
+
+	return nil
+}
+
+func (sigurlx *Sigurlx) SecretsProbe(res Response) ([]string, error) {
+	if res.scan != nil {
+		return res.scan.Secrets, nil
+	}
+
+	var secrets []string
+
+	for name, regex := range sigurlx.secretRegexes {
+		if match := regex.Find(res.Body); match != nil {
+			secrets = append(secrets, name+": "+string(match))
+		}
+	}
+
+	sort.Strings(secrets)
+
+	return secrets, nil
+}
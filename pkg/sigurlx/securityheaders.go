@@ -0,0 +1,37 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+// MissingHeadersProbe lists the security headers an html page doesn't send.
+// HSTS is only expected over https, and a CSP frame-ancestors directive
+// stands in for X-Frame-Options. CSP itself is left to CSPProbe.
+func (sigurlx *Sigurlx) MissingHeadersProbe(parsedURL *url.URL, res Response) ([]string, error) {
+	var missingHeaders []string
+
+	if parsedURL.Scheme == "https" && res.GetHeaderPart("Strict-Transport-Security", ";") == "" {
+		missingHeaders = append(missingHeaders, "Strict-Transport-Security")
+	}
+
+	if res.GetHeaderPart("X-Frame-Options", ",") == "" && !hasFrameAncestors(res) {
+		missingHeaders = append(missingHeaders, "X-Frame-Options")
+	}
+
+	if !strings.EqualFold(strings.TrimSpace(res.GetHeaderPart("X-Content-Type-Options", ",")), "nosniff") {
+		missingHeaders = append(missingHeaders, "X-Content-Type-Options")
+	}
+
+	return missingHeaders, nil
+}
+
+func hasFrameAncestors(res Response) bool {
+	for _, policy := range res.Headers["Content-Security-Policy"] {
+		if _, ok := parseCSP(policy)["frame-ancestors"]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,28 @@
+package sigurlx
+
+import "net/url"
+
+func (sigurlx *Sigurlx) acquireHost(URL string) func() {
+	if sigurlx.Options.PerHostConcurrency <= 0 {
+		return func() {}
+	}
+
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return func() {}
+	}
+
+	sigurlx.hostSemaphoresMutex.Lock()
+	semaphore, ok := sigurlx.hostSemaphores[parsedURL.Host]
+	if !ok {
+		semaphore = make(chan struct{}, sigurlx.Options.PerHostConcurrency)
+		sigurlx.hostSemaphores[parsedURL.Host] = semaphore
+	}
+	sigurlx.hostSemaphoresMutex.Unlock()
+
+	semaphore <- struct{}{}
+
+	return func() {
+		<-semaphore
+	}
+}
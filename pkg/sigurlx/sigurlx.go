@@ -1,77 +1,534 @@
 package sigurlx
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 )
 
+// ErrFiltered is returned for URLs left out of the output by a filter option.
+var ErrFiltered = errors.New("filtered")
+
 type Sigurlx struct {
 	Client       *http.Client
 	Params       []CommonVulnParam
 	Options      *Options
+	APIDOCRegex  *regexp.Regexp
 	JSRegex      *regexp.Regexp
 	DOCRegex     *regexp.Regexp
 	DATARegex    *regexp.Regexp
 	STYLERegex   *regexp.Regexp
 	MEDIARegex   *regexp.Regexp
 	ARCHIVERegex *regexp.Regexp
+	GRAPHQLRegex *regexp.Regexp
 	DOMXSSRegex  *regexp.Regexp
+
+	ctx                 context.Context
+	customCategories    []customCategory
+	checks              map[string]CheckGate
+	domSources          []string
+	domSinks            []string
+	secretRegexes       map[string]*regexp.Regexp
+	paramRegexes        map[string]*regexp.Regexp
+	headers             map[string]string
+	metrics             *metrics
+	invalid             *invalidURLs
+	reflections         *reflectionCache
+	samples             *categorySamples
+	seen                *seenURLs
+	vcsHosts            *vcsHosts
+	hostSemaphores      map[string]chan struct{}
+	hostSemaphoresMutex *sync.Mutex
+	validators          map[string]validator
+	validatorsMutex     *sync.Mutex
 }
 
 func New(options *Options) (Sigurlx, error) {
 	sigurlx := Sigurlx{}
 	sigurlx.Options = options
+	sigurlx.ctx = context.Background()
+	sigurlx.metrics = newMetrics()
+	sigurlx.invalid = &invalidURLs{mutex: &sync.Mutex{}}
+	sigurlx.vcsHosts = &vcsHosts{mutex: &sync.Mutex{}, seen: make(map[string]bool)}
+	sigurlx.reflections = &reflectionCache{mutex: &sync.Mutex{}, verdicts: make(map[string][]ReflectedParam)}
+	sigurlx.samples = &categorySamples{mutex: &sync.Mutex{}, taken: make(map[string]int), skipped: make(map[string]int)}
+	sigurlx.seen = &seenURLs{mutex: &sync.Mutex{}, keys: make(map[string]bool)}
+	sigurlx.hostSemaphores = make(map[string]chan struct{})
+	sigurlx.hostSemaphoresMutex = &sync.Mutex{}
+	sigurlx.validatorsMutex = &sync.Mutex{}
+	sigurlx.paramRegexes = make(map[string]*regexp.Regexp)
 	sigurlx.initCategories()
-	sigurlx.initParams()
-	sigurlx.initClient()
+
+	// the default params file is optional, explicitly given ones aren't
+	if err := sigurlx.initParams(); err != nil && len(options.ParamsFiles) > 0 {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initSecrets(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initChecks(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initRules(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initDOM(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initOutputFields(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initHeaders(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initResponseCache(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initValidators(); err != nil {
+		return sigurlx, err
+	}
+
+	if err := sigurlx.initClient(); err != nil {
+		return sigurlx, err
+	}
 
 	return sigurlx, nil
 }
 
 func (sigurlx *Sigurlx) Process(URL string) (result Result, err error) {
+	return sigurlx.ProcessRequest(RequestSpec{URL: URL})
+}
+
+// ProcessRequest is like Process, but the spec's method, headers and body are
+// used for the main request. Probes following it still send plain requests.
+func (sigurlx *Sigurlx) ProcessRequest(spec RequestSpec) (result Result, err error) {
+	result, err = sigurlx.processRequest(spec)
+
+	atomic.AddInt64(&sigurlx.metrics.reflections, int64(len(result.ReflectedParams)))
+	if err != nil && !errors.Is(err, ErrFiltered) {
+		if result.URL == "" {
+			result.URL = spec.URL
+		}
+
+		result.Error = err.Error()
+		result.ErrorKind = ErrorKind(err)
+	}
+
+	sigurlx.stamp(&result)
+
+	return result, err
+}
+
+// stamp labels result with the scan it belongs to.
+func (sigurlx *Sigurlx) stamp(result *Result) {
+	result.ScanID = sigurlx.Options.ScanID
+	result.Tags = sigurlx.Options.Tags
+}
+
+func (sigurlx *Sigurlx) processRequest(spec RequestSpec) (result Result, err error) {
 	var res Response
 
+	URL := spec.URL
+
+	probeScheme := sigurlx.Options.ProbeScheme && !hasScheme(URL)
+
+	if probeScheme {
+		URL = "https://" + URL
+	}
+
 	parsedURL, err := url.Parse(URL)
 	if err != nil {
 		return result, err
 	}
 
 	result.URL = parsedURL.String()
+	result.Host = parsedURL.Host
+
+	if probeScheme {
+		result.URL = spec.URL
+	}
+
+	var categoryMatch string
+
+	if result.Category, categoryMatch, err = sigurlx.categorize(URL); err != nil {
+		return result, err
+	}
+
+	if sigurlx.Options.CategoryMatch {
+		result.CategoryMatch = categoryMatch
+	}
+
+	result.ThirdParty = sigurlx.isThirdParty(parsedURL)
+
+	if result.ThirdParty && sigurlx.Options.SkipThirdParty {
+		return result, nil
+	}
+
+	if sigurlx.Options.Dedup && !sigurlx.seen.add(sigurlx.dedupKey(parsedURL)) {
+		return result, ErrFiltered
+	}
 
-	if result.Category, err = sigurlx.categorize(URL); err != nil {
+	// sampling happens before any request, that is the work it saves
+	if sigurlx.Options.MaxPerCategory > 0 && !sigurlx.samples.take(result.Category, sigurlx.Options.MaxPerCategory) {
+		return result, ErrFiltered
+	}
+
+	// the main request may go elsewhere than the URL, probes still use the URL
+	requestURL := parsedURL
+
+	if sigurlx.Options.RequestTemplate != nil {
+		rendered, err := sigurlx.Options.RequestTemplate.Render(parsedURL)
+		if err != nil {
+			return result, err
+		}
+
+		if requestURL, err = url.Parse(rendered.URL); err != nil {
+			return result, err
+		}
+
+		// like the body, the spec's own method and headers win
+		if spec.Method == "" {
+			spec.Method = rendered.Method
+		}
+
+		if spec.Body == "" {
+			spec.Body = rendered.Body
+		}
+
+		for header, value := range spec.Headers {
+			if rendered.Headers == nil {
+				rendered.Headers = make(map[string]string)
+			}
+
+			rendered.Headers[header] = value
+		}
+
+		spec.Headers = rendered.Headers
+	}
+
+	// the spec's own body wins over the one set for every request
+	if spec.Body == "" {
+		spec.Body = sigurlx.Options.RequestBody
+	}
+
+	method := spec.Method
+	if method == "" {
+		method = http.MethodGet
+
+		if spec.Body != "" {
+			method = http.MethodPost
+		}
+	}
+
+	var body []byte
+
+	if spec.Body != "" {
+		body = []byte(spec.Body)
+	}
+
+	headers := sigurlx.conditionalHeaders(result.URL)
+
+	if body != nil && sigurlx.Options.RequestContentType != "" {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+
+		headers["Content-Type"] = sigurlx.Options.RequestContentType
+	}
+
+	for header, value := range spec.Headers {
+		if headers == nil {
+			headers = make(map[string]string)
+		}
+
+		headers[header] = value
+	}
+
+	if probeScheme {
+		if res, err = sigurlx.doSchemeRequest(method, requestURL, body, headers); err != nil {
+			return result, err
+		}
+
+		parsedURL.Scheme = requestURL.Scheme
+		result.FinalURL = parsedURL.String()
+	} else if res, err = sigurlx.DoHTTPRequest(method, requestURL.String(), body, headers); err != nil {
 		return result, err
 	}
 
-	if res, err = sigurlx.DoHTTP(parsedURL.String()); err != nil {
+	sigurlx.storeValidators(result.URL, res)
+
+	if !sigurlx.matchStatus(res.StatusCode) {
+		return result, ErrFiltered
+	}
+
+	if res.StatusCode == http.StatusNotModified {
+		result.StatusCode = res.StatusCode
+		result.NotModified = true
+
+		return result, nil
+	}
+
+	// filters out soft-404s and block pages that are served with a 200
+	if sigurlx.Options.FilterBodyRegex != nil && sigurlx.Options.FilterBodyRegex.Match(res.Body) {
+		return result, ErrFiltered
+	}
+
+	if sigurlx.Options.MatchBodyRegex != nil {
+		result.BodyMatch = sigurlx.Options.MatchBodyRegex.Match(res.Body)
+	}
+
+	if err = sigurlx.analyzeResponse(parsedURL, res, &result); err != nil {
 		return result, err
 	}
 
-	result.StatusCode = res.StatusCode
-	result.ContentType = res.ContentType
-	result.ContentLength = res.ContentLength
-	result.RedirectLocation = res.RedirectLocation
+	category := result.Category
+
+	if sigurlx.Options.MethodProbe && sigurlx.runCheck("method_probe", category, parsedURL, true) {
+		if result.AllowedMethods, err = sigurlx.AllowedMethodsProbe(parsedURL.String()); err != nil {
+			return result, err
+		}
+
+		if result.MethodOverride, err = sigurlx.MethodOverrideProbe(parsedURL.String(), res); err != nil {
+			return result, err
+		}
+	}
+
+	if sigurlx.Options.VCS && sigurlx.runCheck("vcs", category, parsedURL, true) {
+		if result.ExposedVCS, err = sigurlx.ExposedVCSProbe(parsedURL); err != nil {
+			return result, err
+		}
+	}
+
+	if sigurlx.Options.HostProbe && sigurlx.runCheck("host_probe", category, parsedURL, true) {
+		if result.HostInjection, err = sigurlx.HostInjectionProbe(parsedURL.String()); err != nil {
+			return result, err
+		}
+	}
 
-	query, err := getQuery(parsedURL.String())
+	if sigurlx.Options.SchemeDiff && parsedURL.Scheme == "https" && sigurlx.runCheck("scheme_diff", category, parsedURL, true) {
+		if result.SchemeDiff, err = sigurlx.SchemeDiffProbe(parsedURL, res); err != nil {
+			return result, err
+		}
+	}
+
+	query, err := sigurlx.getQuery(parsedURL)
 	if err != nil {
 		return result, err
 	}
 
+	fragmentQuery := url.Values{}
+
+	if sigurlx.Options.FragmentParams {
+		fragmentQuery = sigurlx.decodeQuery(getFragmentQuery(parsedURL.Fragment))
+	}
+
+	result.Params = listParams(query, "query")
+	result.Params = append(result.Params, listParams(fragmentQuery, "fragment")...)
+
+	matrixQuery := url.Values{}
+
+	if sigurlx.Options.MatrixParams {
+		matrixQuery = getMatrixQuery(parsedURL.EscapedPath())
+	}
+
+	result.Params = append(result.Params, listParams(matrixQuery, "matrix")...)
+
+	if sigurlx.Options.JWT && sigurlx.runCheck("jwt", category, parsedURL, true) {
+		for _, values := range []url.Values{query, fragmentQuery, matrixQuery} {
+			findings, err := sigurlx.JWTProbe(values)
+			if err != nil {
+				return result, err
+			}
+
+			result.JWTFindings = append(result.JWTFindings, findings...)
+		}
+	}
+
+	if sigurlx.Options.Swagger && sigurlx.runCheck("swagger", category, parsedURL, category == "apidoc") {
+		if result.SwaggerEndpoints, err = sigurlx.SwaggerProbe(res); err != nil {
+			return result, err
+		}
+	}
+
+	if sigurlx.Options.GraphQL && sigurlx.runCheck("graphql", category, parsedURL, category == "graphql") {
+		if result.GraphQL, err = sigurlx.GraphQLProbe(parsedURL); err != nil {
+			return result, err
+		}
+	}
+
+	testParams := sigurlx.shouldTestParams(category)
+
+	if sigurlx.runCheck("upload", category, parsedURL, testParams) {
+		if result.UploadCandidate, err = sigurlx.UploadCandidateProbe(parsedURL, query, res); err != nil {
+			return result, err
+		}
+	}
+
+	if sigurlx.Options.PathReflection && sigurlx.runCheck("path_reflection", category, parsedURL, testParams) {
+		if result.PathReflection, err = sigurlx.PathReflectionProbe(parsedURL); err != nil {
+			return result, err
+		}
+	}
+
+	if len(fragmentQuery) > 0 && sigurlx.runCheck("common_vuln_params", category, parsedURL, testParams) {
+		// fragment params never reach the server, so only their names are checked
+		if result.CommonVulnParams, err = sigurlx.CommonVulnParamsProbe(fragmentQuery); err != nil {
+			return result, err
+		}
+	}
+
 	if len(query) > 0 {
-		if result.Category == "endpoint" {
-			if result.CommonVulnParams, err = sigurlx.CommonVulnParamsProbe(query); err != nil {
+		if sigurlx.runCheck("common_vuln_params", category, parsedURL, testParams) {
+			commonVulnParams, err := sigurlx.CommonVulnParamsProbe(query)
+			if err != nil {
+				return result, err
+			}
+
+			result.CommonVulnParams = append(result.CommonVulnParams, commonVulnParams...)
+		}
+
+		testReflection := sigurlx.runCheck("reflection", category, parsedURL, testParams)
+		testSQLi := sigurlx.Options.SQLi && sigurlx.runCheck("sqli", category, parsedURL, testParams)
+
+		// only the checks comparing against the main response need its body
+		if res.IsEmpty() && (testReflection || testSQLi) {
+			res, _ = sigurlx.DoHTTP(parsedURL.String())
+		}
+
+		probe := sigurlx.ReflectedParamsProbe
+
+		if sigurlx.Options.ReflectionCache {
+			probe = sigurlx.cachedReflectedParamsProbe
+		}
+
+		if testReflection && sigurlx.underReflectMaxBodySize(res) && sigurlx.reflectableContentType(res) {
+			if result.ReflectedParams, err = probe(parsedURL, query, res); err != nil {
 				return result, err
 			}
 
-			if res.IsEmpty() {
-				res, _ = sigurlx.DoHTTP(parsedURL.String())
+			result.TestedParams = sigurlx.reflectionTestedParams(query)
+		}
+
+		if sigurlx.Options.HPP && sigurlx.runCheck("hpp", category, parsedURL, testParams) {
+			if result.HPP, err = sigurlx.HPPProbe(parsedURL, query); err != nil {
+				return result, err
+			}
+		}
+
+		if sigurlx.Options.CRLF && sigurlx.runCheck("crlf", category, parsedURL, testParams) {
+			if result.CRLF, err = sigurlx.CRLFProbe(parsedURL, query); err != nil {
+				return result, err
+			}
+		}
+
+		if sigurlx.Options.OpenRedirect && sigurlx.runCheck("open_redirect", category, parsedURL, testParams) {
+			if result.OpenRedirect, err = sigurlx.OpenRedirectProbe(parsedURL, query); err != nil {
+				return result, err
+			}
+		}
+
+		if testSQLi {
+			if result.SQLi, err = sigurlx.SQLiProbe(parsedURL, query, res); err != nil {
+				return result, err
 			}
+		}
+	}
 
-			if result.ReflectedParams, err = sigurlx.ReflectedParamsProbe(parsedURL, query, res); err != nil {
+	if len(matrixQuery) > 0 {
+		if sigurlx.runCheck("common_vuln_params", category, parsedURL, testParams) {
+			commonVulnParams, err := sigurlx.CommonVulnParamsProbe(matrixQuery)
+			if err != nil {
 				return result, err
 			}
+
+			result.CommonVulnParams = append(result.CommonVulnParams, commonVulnParams...)
+		}
+
+		if sigurlx.runCheck("reflection", category, parsedURL, testParams) && sigurlx.underReflectMaxBodySize(res) && sigurlx.reflectableContentType(res) {
+			reflectedParams, err := sigurlx.MatrixReflectedParamsProbe(parsedURL, matrixQuery, res)
+			if err != nil {
+				return result, err
+			}
+
+			result.ReflectedParams = append(result.ReflectedParams, reflectedParams...)
 		}
 	}
 
+	// keep the main exchange as evidence for findings other than reflections
+	if result.HasFindings() {
+		result.Raw = res.Raw
+	}
+
+	result.Score = sigurlx.score(result)
+
 	return result, nil
 }
+
+func (sigurlx *Sigurlx) matchStatus(statusCode int) bool {
+	if len(sigurlx.Options.MatchStatus) == 0 {
+		return true
+	}
+
+	for _, code := range sigurlx.Options.MatchStatus {
+		if code == statusCode {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (sigurlx *Sigurlx) underReflectMaxBodySize(res Response) bool {
+	if sigurlx.Options.ReflectMaxBodySize <= 0 {
+		return true
+	}
+
+	size := len(res.Body)
+
+	if contentLength, err := strconv.Atoi(res.GetHeaderPart("Content-Length", ";")); err == nil {
+		size = contentLength
+	}
+
+	return size <= sigurlx.Options.ReflectMaxBodySize
+}
+
+// reflectableContentType reports whether res has one of Options.ReflectContentTypes,
+// or of DefaultReflectContentTypes when none are set, which may end in /* to
+// match a whole type. A missing content type may still be sniffed as html, so
+// it is tested.
+func (sigurlx *Sigurlx) reflectableContentType(res Response) bool {
+	contentType := strings.ToLower(strings.TrimSpace(res.ContentType))
+
+	if contentType == "" {
+		return true
+	}
+
+	allowedTypes := sigurlx.Options.ReflectContentTypes
+	if len(allowedTypes) == 0 {
+		allowedTypes = DefaultReflectContentTypes
+	}
+
+	for _, allowed := range allowedTypes {
+		allowed = strings.ToLower(strings.TrimSpace(allowed))
+
+		if allowed == contentType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(contentType, strings.TrimSuffix(allowed, "*"))) {
+			return true
+		}
+	}
+
+	return false
+}
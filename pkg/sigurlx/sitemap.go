@@ -0,0 +1,87 @@
+package sigurlx
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+const maxSitemapDepth = 5
+
+type sitemap struct {
+	XMLName  xml.Name
+	URLs     []sitemapLoc `xml:"url"`
+	Sitemaps []sitemapLoc `xml:"sitemap"`
+}
+
+type sitemapLoc struct {
+	Loc string `xml:"loc"`
+}
+
+func (sigurlx *Sigurlx) FetchSitemap(URL string) ([]string, error) {
+	var URLs []string
+
+	visited := make(map[string]bool)
+
+	if err := sigurlx.fetchSitemap(URL, 0, visited, &URLs); err != nil {
+		return URLs, err
+	}
+
+	return URLs, nil
+}
+
+func (sigurlx *Sigurlx) fetchSitemap(URL string, depth int, visited map[string]bool, URLs *[]string) error {
+	if depth > maxSitemapDepth || visited[URL] {
+		return nil
+	}
+
+	visited[URL] = true
+
+	res, err := sigurlx.DoHTTP(URL)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d for sitemap %s", res.StatusCode, URL)
+	}
+
+	body := res.Body
+
+	// sitemaps are often served as .xml.gz
+	if bytes.HasPrefix(body, []byte{0x1f, 0x8b}) {
+		reader, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+
+		if body, err = ioutil.ReadAll(reader); err != nil {
+			return err
+		}
+	}
+
+	var parsed sitemap
+
+	if err := xml.Unmarshal(body, &parsed); err != nil {
+		return err
+	}
+
+	for _, loc := range parsed.URLs {
+		if loc := strings.TrimSpace(loc.Loc); loc != "" {
+			*URLs = append(*URLs, loc)
+		}
+	}
+
+	for _, loc := range parsed.Sitemaps {
+		if loc := strings.TrimSpace(loc.Loc); loc != "" {
+			// one broken nested sitemap shouldn't lose the others
+			_ = sigurlx.fetchSitemap(loc, depth+1, visited, URLs)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+)
+
+var sqlErrorRegexes = map[string]*regexp.Regexp{
+	"mysql":      regexp.MustCompile(`(?i)(you have an error in your sql syntax|warning: mysqli?_|mysql_fetch_|MySqlException|valid MySQL result)`),
+	"postgresql": regexp.MustCompile(`(?i)(pg_query\(\)|pg_exec\(\)|PSQLException|unterminated quoted string at or near|syntax error at or near)`),
+	"mssql":      regexp.MustCompile(`(?i)(unclosed quotation mark after the character string|microsoft ole db provider for sql server|SqlException|\[SQL Server\])`),
+	"oracle":     regexp.MustCompile(`(?i)(ORA-[0-9]{5}|oracle error|quoted string not properly terminated)`),
+	"sqlite":     regexp.MustCompile(`(?i)(sqlite3?\.OperationalError|SQLITE_ERROR|unrecognized token:)`),
+}
+
+type SQLi struct {
+	Param     string `json:"param,omitempty"`
+	Technique string `json:"technique,omitempty"`
+	DBMS      string `json:"dbms,omitempty"`
+}
+
+func (sigurlx *Sigurlx) SQLiProbe(parsedURL *url.URL, query url.Values, res Response) ([]SQLi, error) {
+	var candidates []SQLi
+
+	params := make([]string, 0, len(query))
+
+	for param := range sigurlx.testedParams(query) {
+		params = append(params, param)
+	}
+
+	sort.Strings(params)
+
+	for _, param := range params {
+		value := query.Get(param)
+
+		quoted, err := sigurlx.requestWithParam(parsedURL, param, value+"'")
+		if err != nil {
+			continue
+		}
+
+		if DBMS := sqlError(quoted.Body, res.Body); DBMS != "" {
+			candidates = append(candidates, SQLi{Param: param, Technique: "error", DBMS: DBMS})
+
+			continue
+		}
+
+		// a true condition keeps the page as it was, a false one changes it
+		truthy, err := sigurlx.requestWithParam(parsedURL, param, value+"' AND '1'='1")
+		if err != nil {
+			continue
+		}
+
+		falsy, err := sigurlx.requestWithParam(parsedURL, param, value+"' AND '1'='2")
+		if err != nil {
+			continue
+		}
+
+		if sameResponse(truthy, res) && !sameResponse(falsy, res) {
+			candidates = append(candidates, SQLi{Param: param, Technique: "boolean"})
+		}
+	}
+
+	return candidates, nil
+}
+
+// sqlError returns the DBMS whose error shows up in body but not in the
+// baseline body.
+func sqlError(body, baseline []byte) string {
+	DBMSs := make([]string, 0, len(sqlErrorRegexes))
+
+	for DBMS := range sqlErrorRegexes {
+		DBMSs = append(DBMSs, DBMS)
+	}
+
+	sort.Strings(DBMSs)
+
+	for _, DBMS := range DBMSs {
+		if sqlErrorRegexes[DBMS].Match(body) && !sqlErrorRegexes[DBMS].Match(baseline) {
+			return DBMS
+		}
+	}
+
+	return ""
+}
+
+func sameResponse(a, b Response) bool {
+	return a.StatusCode == b.StatusCode && similarLength(a.ContentLength, b.ContentLength)
+}
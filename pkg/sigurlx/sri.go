@@ -0,0 +1,60 @@
+package sigurlx
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	subresourceTagRegex = regexp.MustCompile(`(?is)<(script|link)\b[^>]*>`)
+	attributeRegex      = regexp.MustCompile(`(?is)\b([a-z-]+)\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>]+))`)
+)
+
+func (sigurlx *Sigurlx) MissingSRIProbe(parsedURL *url.URL, res Response) ([]string, error) {
+	var missingSRI []string
+
+	for _, tag := range subresourceTagRegex.FindAllStringSubmatch(string(res.Body), -1) {
+		attributes := parseAttributes(tag[0])
+
+		var resource string
+
+		if strings.ToLower(tag[1]) == "script" {
+			resource = attributes["src"]
+		} else if strings.Contains(strings.ToLower(attributes["rel"]), "stylesheet") {
+			resource = attributes["href"]
+		}
+
+		if resource == "" {
+			continue
+		}
+
+		if _, ok := attributes["integrity"]; ok {
+			continue
+		}
+
+		resourceURL, err := parsedURL.Parse(resource)
+		if err != nil {
+			continue
+		}
+
+		// same origin resources are under the site's control anyway
+		if resourceURL.Host == parsedURL.Host {
+			continue
+		}
+
+		missingSRI = append(missingSRI, resourceURL.String())
+	}
+
+	return missingSRI, nil
+}
+
+func parseAttributes(tag string) map[string]string {
+	attributes := make(map[string]string)
+
+	for _, match := range attributeRegex.FindAllStringSubmatch(tag, -1) {
+		attributes[strings.ToLower(match[1])] = match[2] + match[3] + match[4]
+	}
+
+	return attributes
+}
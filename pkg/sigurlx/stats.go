@@ -0,0 +1,65 @@
+package sigurlx
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+)
+
+func ParamStats(results Results) map[string]int {
+	stats := make(map[string]int)
+
+	for _, result := range results {
+		for _, param := range result.Params {
+			stats[param.Name]++
+		}
+	}
+
+	return stats
+}
+
+func CommonVulnParamStats(results Results) map[string]int {
+	stats := make(map[string]int)
+
+	for _, result := range results {
+		for _, param := range result.CommonVulnParams {
+			stats[param.Param]++
+		}
+	}
+
+	return stats
+}
+
+// WriteStatsCSV writes the stats as "param,count" rows, most frequent first.
+func WriteStatsCSV(w io.Writer, stats map[string]int) error {
+	names := make([]string, 0, len(stats))
+
+	for name := range stats {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		if stats[names[i]] == stats[names[j]] {
+			return names[i] < names[j]
+		}
+
+		return stats[names[i]] > stats[names[j]]
+	})
+
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"param", "count"}); err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if err := writer.Write([]string{name, strconv.Itoa(stats[name])}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
@@ -0,0 +1,132 @@
+package sigurlx
+
+import (
+	"io"
+	"io/ioutil"
+	"regexp"
+	"sort"
+)
+
+const (
+	streamChunkSize = 64 * 1024
+	// matches longer than the overlap can be missed across chunk boundaries
+	streamOverlap = 4 * 1024
+)
+
+// streamScan holds the DOM and secret matches of a body that was too large to
+// keep, collected while it was read.
+type streamScan struct {
+	DOM     []string
+	Secrets []string
+}
+
+// readBody reads r into memory, or if that takes more than Options.StreamBodySize
+// bytes, streams the rest through the DOM and secret regexes in overlapping
+// chunks and keeps only the first StreamBodySize bytes. It returns the body,
+// the scan (nil when the whole body was kept) and the number of runes read.
+func (sigurlx *Sigurlx) readBody(r io.Reader) ([]byte, *streamScan, int, error) {
+	limit := sigurlx.Options.StreamBodySize
+
+	body, err := ioutil.ReadAll(io.LimitReader(r, int64(limit)+1))
+	if err != nil || len(body) <= limit {
+		return body, nil, 0, err
+	}
+
+	scanner := newStreamScanner(sigurlx)
+
+	length := scanner.scan(body)
+
+	chunk := make([]byte, streamChunkSize)
+
+	for {
+		n, err := r.Read(chunk)
+
+		length += scanner.scan(chunk[:n])
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return body[:limit], nil, 0, err
+		}
+	}
+
+	return body[:limit], scanner.result(), length, nil
+}
+
+type streamScanner struct {
+	dom     *regexp.Regexp
+	secrets map[string]*regexp.Regexp
+
+	window     []byte
+	domSeen    map[string]bool
+	secretSeen map[string]string
+}
+
+func newStreamScanner(sigurlx *Sigurlx) *streamScanner {
+	return &streamScanner{
+		dom:        sigurlx.DOMXSSRegex,
+		secrets:    sigurlx.secretRegexes,
+		domSeen:    make(map[string]bool),
+		secretSeen: make(map[string]string),
+	}
+}
+
+// scan runs the regexes over the overlap of the previous chunk plus chunk and
+// returns the number of runes in chunk.
+func (scanner *streamScanner) scan(chunk []byte) int {
+	if len(chunk) == 0 {
+		return 0
+	}
+
+	scanner.window = append(scanner.window, chunk...)
+
+	if scanner.dom != nil {
+		for _, match := range scanner.dom.FindAll(scanner.window, -1) {
+			scanner.domSeen[string(match)] = true
+		}
+	}
+
+	for name, regex := range scanner.secrets {
+		if _, ok := scanner.secretSeen[name]; ok {
+			continue
+		}
+
+		if match := regex.Find(scanner.window); match != nil {
+			scanner.secretSeen[name] = string(match)
+		}
+	}
+
+	if len(scanner.window) > streamOverlap {
+		scanner.window = append(scanner.window[:0], scanner.window[len(scanner.window)-streamOverlap:]...)
+	}
+
+	// count rune starts so that runes split across chunks are counted once
+	runes := 0
+
+	for _, b := range chunk {
+		if b&0xC0 != 0x80 {
+			runes++
+		}
+	}
+
+	return runes
+}
+
+func (scanner *streamScanner) result() *streamScan {
+	scan := &streamScan{}
+
+	for match := range scanner.domSeen {
+		scan.DOM = append(scan.DOM, match)
+	}
+
+	for name, match := range scanner.secretSeen {
+		scan.Secrets = append(scan.Secrets, name+": "+match)
+	}
+
+	sort.Strings(scan.DOM)
+	sort.Strings(scan.Secrets)
+
+	return scan
+}
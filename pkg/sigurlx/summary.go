@@ -0,0 +1,113 @@
+package sigurlx
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const histogramWidth = 40
+
+type Summary struct {
+	URLs             int            `json:"urls"`
+	Categories       map[string]int `json:"categories,omitempty"`
+	StatusClasses    map[string]int `json:"status_classes,omitempty"`
+	Errors           map[string]int `json:"errors,omitempty"`
+	Risks            map[string]int `json:"risks,omitempty"`
+	CommonVulnParams int            `json:"common_vuln_params"`
+	ReflectedParams  int            `json:"reflected_params"`
+	DOM              int            `json:"dom"`
+}
+
+func Summarize(results Results) Summary {
+	summary := Summary{
+		URLs:          len(results),
+		Categories:    make(map[string]int),
+		StatusClasses: make(map[string]int),
+		Errors:        make(map[string]int),
+		Risks:         make(map[string]int),
+	}
+
+	for _, result := range results {
+		if result.ErrorKind != "" {
+			summary.Errors[result.ErrorKind]++
+
+			continue
+		}
+
+		summary.Categories[result.Category]++
+
+		if result.StatusCode > 0 {
+			summary.StatusClasses[fmt.Sprintf("%dxx", result.StatusCode/100)]++
+		}
+
+		summary.CommonVulnParams += len(result.CommonVulnParams)
+
+		for _, param := range result.CommonVulnParams {
+			for _, risk := range param.Risks {
+				summary.Risks[risk]++
+			}
+		}
+
+		summary.ReflectedParams += len(result.ReflectedParams)
+
+		if len(result.DOM) > 0 {
+			summary.DOM++
+		}
+	}
+
+	return summary
+}
+
+func (summary Summary) String() string {
+	return fmt.Sprintf(
+		"urls: %d, categories: %v, status: %v, errors: %v, risks: %v, common vuln params: %d, reflected params: %d, dom: %d",
+		summary.URLs,
+		summary.Categories,
+		summary.StatusClasses,
+		summary.Errors,
+		summary.Risks,
+		summary.CommonVulnParams,
+		summary.ReflectedParams,
+		summary.DOM,
+	)
+}
+
+// Histogram renders the category and status class counts as text bars.
+func (summary Summary) Histogram() string {
+	var builder strings.Builder
+
+	histogram := func(title string, counts map[string]int) {
+		var keys []string
+
+		max := 0
+
+		for key, count := range counts {
+			keys = append(keys, key)
+
+			if count > max {
+				max = count
+			}
+		}
+
+		sort.Strings(keys)
+
+		builder.WriteString(title + ":\n")
+
+		for _, key := range keys {
+			bar := strings.Repeat("#", (counts[key]*histogramWidth+max-1)/max)
+			fmt.Fprintf(&builder, "  %-12s %6d %s\n", key, counts[key], bar)
+		}
+	}
+
+	histogram("categories", summary.Categories)
+	histogram("status", summary.StatusClasses)
+
+	if len(summary.Errors) > 0 {
+		histogram("errors", summary.Errors)
+	}
+
+	fmt.Fprintf(&builder, "urls: %d, reflected params: %d, common vuln params: %d\n", summary.URLs, summary.ReflectedParams, summary.CommonVulnParams)
+
+	return builder.String()
+}
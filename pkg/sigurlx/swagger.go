@@ -0,0 +1,92 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+type SwaggerEndpoint struct {
+	Method string   `json:"method,omitempty"`
+	Path   string   `json:"path,omitempty"`
+	Params []string `json:"params,omitempty"`
+}
+
+type swaggerSpec struct {
+	BasePath string                     `json:"basePath" yaml:"basePath"`
+	Paths    map[string]swaggerPathItem `json:"paths" yaml:"paths"`
+}
+
+type swaggerPathItem struct {
+	Get        *swaggerOperation  `json:"get" yaml:"get"`
+	Put        *swaggerOperation  `json:"put" yaml:"put"`
+	Post       *swaggerOperation  `json:"post" yaml:"post"`
+	Delete     *swaggerOperation  `json:"delete" yaml:"delete"`
+	Options    *swaggerOperation  `json:"options" yaml:"options"`
+	Head       *swaggerOperation  `json:"head" yaml:"head"`
+	Patch      *swaggerOperation  `json:"patch" yaml:"patch"`
+	Parameters []swaggerParameter `json:"parameters" yaml:"parameters"`
+}
+
+type swaggerOperation struct {
+	Parameters []swaggerParameter `json:"parameters" yaml:"parameters"`
+}
+
+type swaggerParameter struct {
+	Name string `json:"name" yaml:"name"`
+}
+
+func (sigurlx *Sigurlx) SwaggerProbe(res Response) ([]SwaggerEndpoint, error) {
+	var endpoints []SwaggerEndpoint
+
+	var spec swaggerSpec
+
+	if err := json.Unmarshal(res.Body, &spec); err != nil {
+		if err := yaml.Unmarshal(res.Body, &spec); err != nil {
+			return endpoints, nil
+		}
+	}
+
+	basePath := strings.TrimSuffix(spec.BasePath, "/")
+
+	for path, item := range spec.Paths {
+		operations := map[string]*swaggerOperation{
+			http.MethodGet:     item.Get,
+			http.MethodPut:     item.Put,
+			http.MethodPost:    item.Post,
+			http.MethodDelete:  item.Delete,
+			http.MethodOptions: item.Options,
+			http.MethodHead:    item.Head,
+			http.MethodPatch:   item.Patch,
+		}
+
+		for method, operation := range operations {
+			if operation == nil {
+				continue
+			}
+
+			endpoint := SwaggerEndpoint{Method: method, Path: basePath + path}
+
+			for _, parameter := range append(item.Parameters, operation.Parameters...) {
+				if parameter.Name != "" {
+					endpoint.Params = append(endpoint.Params, parameter.Name)
+				}
+			}
+
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path == endpoints[j].Path {
+			return endpoints[i].Method < endpoints[j].Method
+		}
+
+		return endpoints[i].Path < endpoints[j].Path
+	})
+
+	return endpoints, nil
+}
@@ -0,0 +1,102 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// RequestTemplate describes the main request sent for each URL. Its fields
+// may hold the placeholders:
+//
+//	{{url}}, {{scheme}}, {{host}}, {{path}}, {{query}}  parts of the URL
+//	{{params}}                                          the query as a JSON object
+//	{{param}}, {{value}}                                the first query param, by name
+//
+// e.g a body of {{params}} sends the query params as JSON. Values are not
+// escaped, except in {{params}}.
+type RequestTemplate struct {
+	Method  string            `yaml:"method" json:"method"`
+	URL     string            `yaml:"url" json:"url"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+	Body    string            `yaml:"body" json:"body"`
+}
+
+// LoadRequestTemplate reads a YAML (or JSON) request template.
+func LoadRequestTemplate(file string) (*RequestTemplate, error) {
+	raw, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var template RequestTemplate
+
+	if err = yaml.Unmarshal(raw, &template); err != nil {
+		return nil, fmt.Errorf("invalid request template: %w", err)
+	}
+
+	return &template, nil
+}
+
+// Render fills the template in for parsedURL. An empty template URL keeps
+// parsedURL.
+func (template *RequestTemplate) Render(parsedURL *url.URL) (RequestSpec, error) {
+	query := parsedURL.Query()
+
+	params := make(map[string]string)
+	names := make([]string, 0, len(query))
+
+	for name := range query {
+		params[name] = query.Get(name)
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	JSON, err := json.Marshal(params)
+	if err != nil {
+		return RequestSpec{}, err
+	}
+
+	var param, value string
+
+	if len(names) > 0 {
+		param, value = names[0], params[names[0]]
+	}
+
+	replacer := strings.NewReplacer(
+		"{{url}}", parsedURL.String(),
+		"{{scheme}}", parsedURL.Scheme,
+		"{{host}}", parsedURL.Host,
+		"{{path}}", parsedURL.EscapedPath(),
+		"{{query}}", parsedURL.RawQuery,
+		"{{params}}", string(JSON),
+		"{{param}}", param,
+		"{{value}}", value,
+	)
+
+	spec := RequestSpec{
+		URL:    parsedURL.String(),
+		Method: replacer.Replace(template.Method),
+		Body:   replacer.Replace(template.Body),
+	}
+
+	if template.URL != "" {
+		spec.URL = replacer.Replace(template.URL)
+	}
+
+	if len(template.Headers) > 0 {
+		spec.Headers = make(map[string]string)
+
+		for header, value := range template.Headers {
+			spec.Headers[header] = replacer.Replace(value)
+		}
+	}
+
+	return spec, nil
+}
@@ -0,0 +1,51 @@
+package sigurlx
+
+import (
+	"crypto/tls"
+	"fmt"
+)
+
+var tlsVersions = map[uint16]string{
+	tls.VersionTLS10: "TLS 1.0",
+	tls.VersionTLS11: "TLS 1.1",
+	tls.VersionTLS12: "TLS 1.2",
+	tls.VersionTLS13: "TLS 1.3",
+}
+
+// cipherSuites returns every suite Go implements, insecure ones included, so
+// that servers still preferring weak suites can be spotted.
+func cipherSuites() []uint16 {
+	var IDs []uint16
+
+	for _, suite := range tls.CipherSuites() {
+		IDs = append(IDs, suite.ID)
+	}
+
+	for _, suite := range tls.InsecureCipherSuites() {
+		IDs = append(IDs, suite.ID)
+	}
+
+	return IDs
+}
+
+func tlsVersionName(version uint16) string {
+	if name, ok := tlsVersions[version]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("0x%04X", version)
+}
+
+func isWeakTLS(state *tls.ConnectionState) bool {
+	if state.Version < tls.VersionTLS12 {
+		return true
+	}
+
+	for _, suite := range tls.InsecureCipherSuites() {
+		if suite.ID == state.CipherSuite {
+			return true
+		}
+	}
+
+	return false
+}
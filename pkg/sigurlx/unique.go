@@ -0,0 +1,84 @@
+package sigurlx
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Finding is one of a batch's unique findings, URL being the first of the
+// Count URLs it was found on.
+type Finding struct {
+	Host    string `json:"host,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Param   string `json:"param,omitempty"`
+	Context string `json:"context,omitempty"`
+	URL     string `json:"url,omitempty"`
+	Count   int    `json:"count,omitempty"`
+}
+
+// UniqueFindings collapses the reflected params, common vuln params and DOM
+// matches of results that share a host, param, type and context, e.g the same
+// callback param reflected in the same way across a templated site.
+func UniqueFindings(results Results) []Finding {
+	var findings []Finding
+
+	index := make(map[Finding]int)
+
+	add := func(finding Finding, URL string) {
+		i, ok := index[finding]
+		if !ok {
+			i = len(findings)
+			index[finding] = i
+
+			finding.URL = URL
+			findings = append(findings, finding)
+		}
+
+		findings[i].Count++
+	}
+
+	for _, result := range results {
+		for _, param := range result.ReflectedParams {
+			context := strings.Join(param.Characters, "")
+
+			for _, part := range []string{param.Location, param.Context, param.Encoding} {
+				if part != "" {
+					context += " " + part
+				}
+			}
+
+			add(Finding{Host: result.Host, Type: "reflected_param", Param: param.Param, Context: context}, result.URL)
+		}
+
+		for _, param := range result.CommonVulnParams {
+			add(Finding{Host: result.Host, Type: "common_vuln_param", Param: param.Param, Context: strings.Join(param.Risks, ",")}, result.URL)
+		}
+
+		for _, match := range result.DOM {
+			add(Finding{Host: result.Host, Type: "dom", Context: match}, result.URL)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].Host != findings[j].Host {
+			return findings[i].Host < findings[j].Host
+		}
+
+		return findings[i].Type < findings[j].Type
+	})
+
+	return findings
+}
+
+func WriteUniqueFindings(w io.Writer, findings []Finding) error {
+	JSON, err := json.MarshalIndent(findings, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(JSON, '\n'))
+
+	return err
+}
@@ -0,0 +1,61 @@
+package sigurlx
+
+import (
+	"net/url"
+	"strings"
+)
+
+var uploadKeywords = []string{"upload", "attach", "file", "import", "avatar"}
+
+func (sigurlx *Sigurlx) UploadCandidateProbe(parsedURL *url.URL, query url.Values, res Response) (bool, error) {
+	for parameter := range query {
+		if hasUploadKeyword(parameter) {
+			return true, nil
+		}
+	}
+
+	for _, segment := range strings.Split(parsedURL.Path, "/") {
+		if hasUploadKeyword(segment) {
+			return true, nil
+		}
+	}
+
+	if res.IsEmpty() {
+		return false, nil
+	}
+
+	// an upload endpoint accepts writes and hints at multipart bodies
+	allow := strings.ToUpper(strings.Join(res.Headers["Allow"], ","))
+
+	if !strings.Contains(allow, "POST") && !strings.Contains(allow, "PUT") {
+		return false, nil
+	}
+
+	acceptPost := strings.ToLower(strings.Join(res.Headers["Accept-Post"], ","))
+	body := strings.ToLower(string(res.Body))
+
+	if strings.Contains(acceptPost, "multipart/form-data") ||
+		strings.Contains(body, "multipart/form-data") ||
+		strings.Contains(body, `type="file"`) {
+		return true, nil
+	}
+
+	return false, nil
+}
+
+func hasUploadKeyword(s string) bool {
+	tokens := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == '[' || r == ']'
+	})
+
+	for _, token := range tokens {
+		for _, keyword := range uploadKeywords {
+			// prefix match so that "uploads" matches but "profile" doesn't
+			if strings.HasPrefix(token, keyword) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
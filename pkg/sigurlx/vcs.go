@@ -0,0 +1,67 @@
+package sigurlx
+
+import (
+	"bytes"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+)
+
+// each path is confirmed by its content so that soft-404s don't count
+var vcsPaths = []struct {
+	path    string
+	confirm func(body []byte) bool
+}{
+	{"/.git/HEAD", regexp.MustCompile(`^(ref: refs/|[0-9a-f]{40}\s*$)`).Match},
+	{"/.git/config", func(body []byte) bool { return bytes.Contains(body, []byte("[core]")) }},
+	{"/.svn/entries", regexp.MustCompile(`^([0-9]+\s|<\?xml[^>]*>\s*<wc-entries)`).Match},
+	{"/.svn/wc.db", func(body []byte) bool { return bytes.HasPrefix(body, []byte("SQLite format 3")) }},
+	{"/.hg/requires", func(body []byte) bool { return bytes.Contains(body, []byte("revlogv1")) }},
+	{"/.bzr/README", func(body []byte) bool { return bytes.Contains(body, []byte("Bazaar")) }},
+	{"/.env", regexp.MustCompile(`(?m)^[A-Z][A-Z0-9_]*=\S`).Match},
+	{"/.DS_Store", func(body []byte) bool { return bytes.HasPrefix(body, []byte("\x00\x00\x00\x01Bud1")) }},
+}
+
+type vcsHosts struct {
+	mutex *sync.Mutex
+	seen  map[string]bool
+}
+
+// claim reports whether host is seen for the first time.
+func (hosts *vcsHosts) claim(host string) bool {
+	hosts.mutex.Lock()
+	defer hosts.mutex.Unlock()
+
+	if hosts.seen[host] {
+		return false
+	}
+
+	hosts.seen[host] = true
+
+	return true
+}
+
+// ExposedVCSProbe checks every host once, later urls of a host get nothing.
+func (sigurlx *Sigurlx) ExposedVCSProbe(parsedURL *url.URL) ([]string, error) {
+	var exposed []string
+
+	if !sigurlx.vcsHosts.claim(parsedURL.Scheme + "://" + parsedURL.Host) {
+		return exposed, nil
+	}
+
+	for _, vcs := range vcsPaths {
+		vcsURL := url.URL{Scheme: parsedURL.Scheme, Host: parsedURL.Host, Path: vcs.path}
+
+		res, err := sigurlx.DoHTTP(vcsURL.String())
+		if err != nil {
+			continue
+		}
+
+		if res.StatusCode == http.StatusOK && vcs.confirm(res.Body) {
+			exposed = append(exposed, vcsURL.String())
+		}
+	}
+
+	return exposed, nil
+}